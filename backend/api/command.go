@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	mongo_infra "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo"
+	store_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/store"
+	survey_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/survey"
+	store_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/store"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	commandReconcileStores       = "reconcile-stores"
+	commandRecalculateStoreStats = "recalculate-store-stats"
+	commandReindexSearch         = "reindex-search"
+)
+
+// runCommand はサーバー起動の代わりに運用向けのサブコマンドを実行する。
+func runCommand(c config, name string, args []string) {
+	switch name {
+	case commandReconcileStores:
+		reconcileStores(c, args)
+	case commandRecalculateStoreStats:
+		recalculateStoreStats(c)
+	case commandReindexSearch:
+		reindexSearch(c)
+	default:
+		c.logger.Fatalf("unknown command %q (available: %s, %s, %s)", name, commandReconcileStores, commandRecalculateStoreStats, commandReindexSearch)
+	}
+}
+
+// reconcileStores はアンケートに複製された店舗情報を現在の店舗情報に揃える。
+// -dry-run を指定した場合は更新せずに対象件数のみ表示する。
+func reconcileStores(c config, args []string) {
+	flags := flag.NewFlagSet(commandReconcileStores, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "report drifted surveys without updating them")
+	_ = flags.Parse(args)
+
+	client := connectCommandMongo(c)
+	defer disconnectMongo(c, client)
+	storeService := newCommandStoreService(c, client)
+
+	result, err := storeService.ReconcileSurveys(context.Background(), *dryRun)
+	if err != nil {
+		c.logger.Fatalf("reconcile stopped after %d stores: %v", result.Stores, err)
+	}
+	if *dryRun {
+		c.logger.Printf("dry run: %d surveys across %d of %d stores have stale store data", result.Surveys, result.DriftedStores, result.Stores)
+		return
+	}
+	c.logger.Printf("reconciled %d surveys across %d of %d stores", result.Surveys, result.DriftedStores, result.Stores)
+}
+
+// recalculateStoreStats は全店舗の統計をアンケートから再集計する。
+func recalculateStoreStats(c config) {
+	client := connectCommandMongo(c)
+	defer disconnectMongo(c, client)
+	storeService := newCommandStoreService(c, client)
+
+	result, err := storeService.RecalculateStats(context.Background())
+	if err != nil {
+		c.logger.Fatalf("recalculate stopped after %d stores: %v", result.Stores, err)
+	}
+	c.logger.Printf("recalculated stats for %d stores (%d with surveys)", result.Stores, result.StoresWithSurveys)
+}
+
+// reindexSearch は店舗名の検索キーと、アンケートのコメントの全文検索用の索引を作り直す。
+func reindexSearch(c config) {
+	client := connectCommandMongo(c)
+	defer disconnectMongo(c, client)
+	database := client.Database(c.mongoDatabase)
+	storeRepo := store_mongo.NewRepo(
+		database.Collection(c.storeCollection),
+		database.Collection(c.surveyCollection),
+	)
+	surveyRepo := survey_mongo.NewRepo(database.Collection(c.surveyCollection))
+
+	ctx := context.Background()
+	stores, err := storeRepo.ReindexSearchKeys(ctx)
+	if err != nil {
+		c.logger.Fatalf("reindex stopped after %d stores: %v", stores, err)
+	}
+	if err := surveyRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure survey indexes: %v", err)
+	}
+	surveys, err := surveyRepo.ReindexSearch(ctx)
+	if err != nil {
+		c.logger.Fatalf("reindex stopped after %d surveys: %v", surveys, err)
+	}
+	c.logger.Printf("reindexed search keys of %d stores and %d surveys", stores, surveys)
+}
+
+func connectCommandMongo(c config) *mongo.Client {
+	ctx, cancel := context.WithTimeout(context.Background(), c.connectTimeout)
+	defer cancel()
+	return connectMongo(ctx, c)
+}
+
+func disconnectMongo(c config, client *mongo.Client) {
+	if err := client.Disconnect(context.Background()); err != nil {
+		c.logger.Printf("failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// newCommandStoreService はサブコマンド用に店舗ユースケースを組み立てる。
+func newCommandStoreService(c config, client *mongo.Client) store_usecase.Service {
+	database := client.Database(c.mongoDatabase)
+	surveyRepo := survey_mongo.NewRepo(database.Collection(c.surveyCollection))
+	storeRepo := store_mongo.NewRepo(
+		database.Collection(c.storeCollection),
+		database.Collection(c.surveyCollection),
+	)
+	return store_usecase.NewService(storeRepo, surveyRepo, mongo_infra.NewTransactor(client), c.storeDeletePolicy)
+}
@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"errors"
+
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+)
+
+// Admin は管理画面にログインできるアカウントの集約を表す。
+type Admin struct {
+	id           admin_vo.ID
+	email        admin_vo.Email
+	passwordHash admin_vo.PasswordHash
+	role         admin_vo.Role
+	lastLoginAt  *common_vo.Timestamp
+	createdAt    common_vo.Timestamp
+	updatedAt    common_vo.Timestamp
+}
+
+// Option は Admin 生成時のオプションを表す。
+type Option func(*Admin) error
+
+// WithLastLoginAt は最終ログイン日時を設定する。
+func WithLastLoginAt(ts common_vo.Timestamp) Option {
+	return func(a *Admin) error {
+		t := ts
+		a.lastLoginAt = &t
+		return nil
+	}
+}
+
+// WithCreatedAt は作成日時を設定する。
+func WithCreatedAt(ts common_vo.Timestamp) Option {
+	return func(a *Admin) error {
+		a.createdAt = ts
+		return nil
+	}
+}
+
+// WithUpdatedAt は更新日時を設定する。
+func WithUpdatedAt(ts common_vo.Timestamp) Option {
+	return func(a *Admin) error {
+		a.updatedAt = ts
+		return nil
+	}
+}
+
+// NewAdmin は必須の VO を検証し、管理者エンティティを生成する。
+func NewAdmin(
+	id admin_vo.ID,
+	email admin_vo.Email,
+	passwordHash admin_vo.PasswordHash,
+	role admin_vo.Role,
+	opts ...Option,
+) (*Admin, error) {
+	admin := &Admin{
+		id:           id,
+		email:        email,
+		passwordHash: passwordHash,
+		role:         role,
+	}
+
+	for _, opt := range opts {
+		if err := opt(admin); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := admin.validate(); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (a *Admin) validate() error {
+	if !a.id.Validate() {
+		return errors.New("管理者IDの入力値が不正です")
+	}
+	if !a.email.Validate() {
+		return errors.New("メールアドレスの入力値が不正です")
+	}
+	if !a.passwordHash.Validate() {
+		return errors.New("パスワードハッシュの入力値が不正です")
+	}
+	if !a.role.Validate() {
+		return errors.New("ロールの入力値が不正です")
+	}
+	if a.createdAt.IsZero() {
+		a.createdAt = common_vo.NowTimestamp()
+	}
+	if a.updatedAt.IsZero() {
+		a.updatedAt = a.createdAt
+	}
+	if !a.createdAt.Validate() || !a.updatedAt.Validate() {
+		return errors.New("タイムスタンプの入力値が不正です")
+	}
+	if a.lastLoginAt != nil && !a.lastLoginAt.Validate() {
+		return errors.New("最終ログイン日時の入力値が不正です")
+	}
+	return nil
+}
+
+// Authenticate は平文パスワードが登録済みのハッシュと一致するか判定する。
+func (a *Admin) Authenticate(password string) bool {
+	return a.passwordHash.Matches(password)
+}
+
+// RecordLogin はログイン成功時刻を記録する。
+func (a *Admin) RecordLogin(at common_vo.Timestamp) {
+	t := at
+	a.lastLoginAt = &t
+	a.updatedAt = at
+}
+
+// ChangeRole はロールを変更する。
+func (a *Admin) ChangeRole(role admin_vo.Role, at common_vo.Timestamp) error {
+	if !role.Validate() {
+		return errors.New("ロールの入力値が不正です")
+	}
+	a.role = role
+	a.updatedAt = at
+	return nil
+}
+
+// Can は管理者のロールが指定の権限を持つか判定する。
+func (a *Admin) Can(permission admin_vo.Permission) bool {
+	return a.role.Can(permission)
+}
+
+// ID は管理者IDを返す。
+func (a *Admin) ID() admin_vo.ID {
+	return a.id
+}
+
+// Email はログイン用メールアドレスを返す。
+func (a *Admin) Email() admin_vo.Email {
+	return a.email
+}
+
+// PasswordHash はパスワードハッシュを返す。
+func (a *Admin) PasswordHash() admin_vo.PasswordHash {
+	return a.passwordHash
+}
+
+// Role はロールを返す。
+func (a *Admin) Role() admin_vo.Role {
+	return a.role
+}
+
+// LastLoginAt は最終ログイン日時を返す（未ログインの場合は nil）。
+func (a *Admin) LastLoginAt() *common_vo.Timestamp {
+	return a.lastLoginAt
+}
+
+// CreatedAt は作成日時を返す。
+func (a *Admin) CreatedAt() common_vo.Timestamp {
+	return a.createdAt
+}
+
+// UpdatedAt は更新日時を返す。
+func (a *Admin) UpdatedAt() common_vo.Timestamp {
+	return a.updatedAt
+}
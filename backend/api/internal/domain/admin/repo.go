@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"context"
+
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+)
+
+// Repo は Admin 集約の永続化操作を提供する。
+// Find 系は該当がない場合 (nil, nil) を返す。
+type Repo interface {
+	Save(context.Context, *Admin) error
+	FindByID(context.Context, admin_vo.ID) (*Admin, error)
+	FindByEmail(context.Context, admin_vo.Email) (*Admin, error)
+	FindAll(context.Context) ([]*Admin, error)
+	Count(context.Context) (int64, error)
+	CountByRole(context.Context, admin_vo.Role) (int64, error)
+}
@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Diff は 2 つのスナップショットを比較し、値が異なるフィールドを名前順に返す。
+// ネストしたオブジェクトは "businessHours.open" のようにドット区切りで展開して比較する。
+// before/after のどちらかが nil の場合は、もう一方の全フィールドを差分として扱う。
+func Diff(before, after map[string]interface{}) []Change {
+	flatBefore := map[string]interface{}{}
+	flatAfter := map[string]interface{}{}
+	flatten("", before, flatBefore)
+	flatten("", after, flatAfter)
+
+	fields := make([]string, 0, len(flatBefore)+len(flatAfter))
+	seen := map[string]struct{}{}
+	for _, m := range []map[string]interface{}{flatBefore, flatAfter} {
+		for field := range m {
+			if _, ok := seen[field]; ok {
+				continue
+			}
+			seen[field] = struct{}{}
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]Change, 0, len(fields))
+	for _, field := range fields {
+		b, a := flatBefore[field], flatAfter[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes = append(changes, Change{Field: field, Before: b, After: a})
+	}
+	return changes
+}
+
+func flatten(prefix string, src map[string]interface{}, dst map[string]interface{}) {
+	for key, value := range src {
+		field := key
+		if prefix != "" {
+			field = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flatten(field, nested, dst)
+			continue
+		}
+		dst[field] = value
+	}
+}
@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"errors"
+	"strings"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+)
+
+// EntityType は監査対象の集約種別を表す。
+type EntityType string
+
+const (
+	// EntityStore は店舗集約。
+	EntityStore EntityType = "store"
+	// EntitySurvey はアンケート集約。
+	EntitySurvey EntityType = "survey"
+)
+
+// Action は監査対象の操作種別を表す。
+type Action string
+
+const (
+	// ActionCreate は新規作成。
+	ActionCreate Action = "create"
+	// ActionUpdate は更新。
+	ActionUpdate Action = "update"
+	// ActionDelete は削除（論理削除）。
+	ActionDelete Action = "delete"
+	// ActionRestore は論理削除からの復元。
+	ActionRestore Action = "restore"
+	// ActionPurge は物理削除。
+	ActionPurge Action = "purge"
+)
+
+// Change はフィールド単位の変更内容を表す。作成時の Before、削除時の After は nil になる。
+type Change struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// Entry は管理操作 1 回分の監査ログを表す。
+// 追記専用のため、生成後に内容を変更するメソッドは持たない。
+type Entry struct {
+	id         string
+	actor      string
+	requestID  string
+	entityType EntityType
+	entityID   string
+	action     Action
+	changes    []Change
+	occurredAt common_vo.Timestamp
+}
+
+// Option は Entry 生成時のオプションを表す。
+type Option func(*Entry) error
+
+// WithID は永続化済みエントリの ID を設定する。
+func WithID(id string) Option {
+	return func(e *Entry) error {
+		e.id = id
+		return nil
+	}
+}
+
+// WithRequestID はリクエスト ID を設定する。
+func WithRequestID(requestID string) Option {
+	return func(e *Entry) error {
+		e.requestID = strings.TrimSpace(requestID)
+		return nil
+	}
+}
+
+// WithOccurredAt は操作日時を設定する。未指定の場合は現在時刻となる。
+func WithOccurredAt(ts common_vo.Timestamp) Option {
+	return func(e *Entry) error {
+		e.occurredAt = ts
+		return nil
+	}
+}
+
+// NewEntry は監査ログを生成する。
+func NewEntry(
+	actor string,
+	entityType EntityType,
+	entityID string,
+	action Action,
+	changes []Change,
+	opts ...Option,
+) (*Entry, error) {
+	e := &Entry{
+		actor:      strings.TrimSpace(actor),
+		entityType: entityType,
+		entityID:   strings.TrimSpace(entityID),
+		action:     action,
+		changes:    changes,
+	}
+
+	for _, opt := range opts {
+		if err := opt(e); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Entry) validate() error {
+	if e.actor == "" {
+		return errors.New("監査ログの操作者が不正です")
+	}
+	if e.entityType == "" {
+		return errors.New("監査ログの対象種別が不正です")
+	}
+	if e.entityID == "" {
+		return errors.New("監査ログの対象IDが不正です")
+	}
+	switch e.action {
+	case ActionCreate, ActionUpdate, ActionDelete, ActionRestore, ActionPurge:
+	default:
+		return errors.New("監査ログの操作種別が不正です")
+	}
+	if e.occurredAt.IsZero() {
+		e.occurredAt = common_vo.NowTimestamp()
+	}
+	return nil
+}
+
+// ID は監査ログ ID を返す（未保存の場合は空文字）。
+func (e *Entry) ID() string {
+	return e.id
+}
+
+// Actor は操作した管理者の subject を返す。
+func (e *Entry) Actor() string {
+	return e.actor
+}
+
+// RequestID は操作時の HTTP リクエスト ID を返す。
+func (e *Entry) RequestID() string {
+	return e.requestID
+}
+
+// EntityType は対象の集約種別を返す。
+func (e *Entry) EntityType() EntityType {
+	return e.entityType
+}
+
+// EntityID は対象の集約 ID を返す。
+func (e *Entry) EntityID() string {
+	return e.entityID
+}
+
+// Action は操作種別を返す。
+func (e *Entry) Action() Action {
+	return e.action
+}
+
+// Changes はフィールド単位の差分を返す。
+func (e *Entry) Changes() []Change {
+	return e.changes
+}
+
+// OccurredAt は操作日時を返す。
+func (e *Entry) OccurredAt() common_vo.Timestamp {
+	return e.occurredAt
+}
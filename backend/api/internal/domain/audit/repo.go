@@ -0,0 +1,20 @@
+package audit
+
+import (
+	"context"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+)
+
+// Repo は監査ログの永続化操作を提供する。
+type Repo interface {
+	Save(context.Context, *Entry) error
+	Find(context.Context, Filter, common_vo.Pagination) ([]*Entry, int64, error)
+}
+
+// Filter は監査ログの検索条件を表す。空の項目は条件に含めない。
+type Filter struct {
+	EntityType EntityType
+	EntityID   string
+	Actor      string
+}
@@ -0,0 +1,64 @@
+// Package dashboard は管理画面のダッシュボードに表示する運用指標を扱う。
+// 店舗・アンケート・投稿の各コレクションを横断して集計する読み取り専用のモデルで、集約は持たない。
+package dashboard
+
+import (
+	"time"
+
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+// Granularity は件数の推移をまとめる単位を表す。
+type Granularity string
+
+const (
+	GranularityDay  Granularity = "day"
+	GranularityWeek Granularity = "week"
+)
+
+// Window は件数の推移を集計する期間を表す。
+// Since 以降を Granularity ごとに区切り、日・週の境界は Timezone (IANA 名) で判定する。週は月曜始まり。
+type Window struct {
+	Since       time.Time
+	Granularity Granularity
+	Timezone    string
+}
+
+// CountPoint は 1 期間分の件数を表す。Start は期間の開始日時。
+type CountPoint struct {
+	Start time.Time
+	Count int64
+}
+
+// PendingCounts は管理者の対応待ちの件数を表す。
+// Submissions は確認待ちの一般投稿、Surveys は承認待ちのアンケート。
+type PendingCounts struct {
+	Submissions int64
+	Surveys     int64
+}
+
+// StoreActivity は店舗とアンケートの投稿状況を表す。
+// LastSurveyedAt は承認済みアンケートのうち最新の作成日時で、アンケートがない場合は nil。
+type StoreActivity struct {
+	StoreID        store_vo.ID
+	Name           store_vo.Name
+	BranchName     *store_vo.BranchName
+	Prefecture     store_vo.Prefecture
+	CreatedAt      time.Time
+	SurveyCount    int
+	LastSurveyedAt *time.Time
+}
+
+// PrefectureActivity は都道府県ごとの期間内の動きを表す。
+// 投稿の都道府県は入力のままのため、正規の都道府県名でない値が含まれることがある。
+type PrefectureActivity struct {
+	Prefecture  string
+	Surveys     int64
+	Submissions int64
+	NewStores   int64
+}
+
+// Total は期間内の動きの合計を返す。並び替えの基準に使う。
+func (a PrefectureActivity) Total() int64 {
+	return a.Surveys + a.Submissions + a.NewStores
+}
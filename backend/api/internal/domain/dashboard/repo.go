@@ -0,0 +1,19 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+)
+
+// Repo はダッシュボードの集計を提供する。論理削除された店舗・アンケートは集計に含めない。
+// アンケートの公開日は承認日時、承認日時がない既存ドキュメントは作成日時とする。
+// StoresWithoutSurveys/StaleStores は該当する店舗の総数と、古い順に limit 件を返す。
+type Repo interface {
+	SubmissionCounts(context.Context, Window) ([]CountPoint, error)
+	PublishedSurveyCounts(context.Context, Window) ([]CountPoint, error)
+	NewStoreCounts(context.Context, Window) ([]CountPoint, error)
+	PendingCounts(context.Context) (PendingCounts, error)
+	StoresWithoutSurveys(ctx context.Context, limit int) ([]StoreActivity, int64, error)
+	StaleStores(ctx context.Context, before time.Time, limit int) ([]StoreActivity, int64, error)
+	TopPrefectures(ctx context.Context, since time.Time, limit int) ([]PrefectureActivity, error)
+}
@@ -0,0 +1,210 @@
+// Package fulltext は自由記述の文章を日本語向けに全文検索するための分かち書きと抜粋を扱う。
+// 日本語は単語の区切りがないため、文字単位の n-gram（1 文字と 2 文字）を索引の語とする。
+// 永続化や HTTP には依存せず、文字列だけを扱う。
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultSnippetLength は抜粋の長さ(文字数)の既定値。
+const DefaultSnippetLength = 80
+
+// snippetEllipsis は抜粋の前後を省略したことを表す。
+const snippetEllipsis = "…"
+
+// Segment は抜粋を検索語に一致した部分とそれ以外に区切ったもの。
+type Segment struct {
+	Text  string
+	Match bool
+}
+
+// IndexText は texts を分かち書きした語を空白区切りで並べた索引用の文字列を返す。
+// 出現回数を関連度に反映できるよう、同じ語も重複して並べる。
+func IndexText(texts ...string) string {
+	var b strings.Builder
+	for _, text := range texts {
+		for _, word := range words(text) {
+			for _, token := range ngrams(word) {
+				if b.Len() > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(token)
+			}
+		}
+	}
+	return b.String()
+}
+
+// QueryTerms は検索語を分かち書きし、索引と照合する語を重複なく返す。
+// 2 文字以上の語は 2 文字ずつ、1 文字の語はそのまま切り出す。検索できる文字がない場合は nil を返す。
+func QueryTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]struct{})
+	for _, word := range words(query) {
+		runes := []rune(word)
+		if len(runes) == 1 {
+			if _, ok := seen[word]; !ok {
+				seen[word] = struct{}{}
+				terms = append(terms, word)
+			}
+			continue
+		}
+		for i := 0; i+2 <= len(runes); i++ {
+			term := string(runes[i : i+2])
+			if _, ok := seen[term]; ok {
+				continue
+			}
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Highlight は text のうち検索語に一致する部分を含む抜粋を返す。
+// 抜粋は最初に一致した位置を中心に length 文字程度とし、省略した前後には「…」を付ける。
+// 一致する部分がない場合は false を返す。
+func Highlight(text, query string, length int) ([]Segment, bool) {
+	if length <= 0 {
+		length = DefaultSnippetLength
+	}
+	matched := matchedRunes(text, words(query))
+	first := -1
+	for i, m := range matched {
+		if m {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil, false
+	}
+
+	runes := []rune(text)
+	start := first - length/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + length
+	if end > len(runes) {
+		end = len(runes)
+		if start = end - length; start < 0 {
+			start = 0
+		}
+	}
+
+	var segments []Segment
+	for i := start; i < end; i++ {
+		if n := len(segments); n > 0 && segments[n-1].Match == matched[i] {
+			segments[n-1].Text += string(runes[i])
+			continue
+		}
+		segments = append(segments, Segment{Text: string(runes[i]), Match: matched[i]})
+	}
+	if start > 0 {
+		segments = append([]Segment{{Text: snippetEllipsis}}, segments...)
+	}
+	if end < len(runes) {
+		segments = append(segments, Segment{Text: snippetEllipsis})
+	}
+	return mergeSegments(segments), true
+}
+
+// mergeSegments は一致しない部分が続く場合に 1 つにまとめる。
+func mergeSegments(segments []Segment) []Segment {
+	merged := segments[:0]
+	for _, s := range segments {
+		if n := len(merged); n > 0 && !s.Match && !merged[n-1].Match {
+			merged[n-1].Text += s.Text
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// matchedRunes は text の各文字が検索語のいずれかに一致する部分に含まれるかを返す。
+// 全角/半角の違いなどを吸収するため、正規化後の文字列で照合し、元の文字の位置に戻す。
+func matchedRunes(text string, terms []string) []bool {
+	type normalizedRune struct {
+		r      rune
+		origin int // 元の文字列での文字の位置
+	}
+
+	var (
+		normalized []normalizedRune
+		it         norm.Iter
+		offsets    = runeOffsets(text)
+	)
+	it.InitString(norm.NFKC, text)
+	for !it.Done() {
+		pos := it.Pos()
+		segment := strings.ToLower(string(it.Next()))
+		for _, r := range segment {
+			normalized = append(normalized, normalizedRune{r: r, origin: offsets[pos]})
+		}
+	}
+
+	matched := make([]bool, offsets[len(text)])
+	for _, term := range terms {
+		want := []rune(term)
+		for i := 0; i+len(want) <= len(normalized); i++ {
+			ok := true
+			for j, r := range want {
+				if normalized[i+j].r != r {
+					ok = false
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+			from := normalized[i].origin
+			to := len(matched)
+			if k := i + len(want); k < len(normalized) {
+				to = normalized[k].origin
+			}
+			for p := from; p < to; p++ {
+				matched[p] = true
+			}
+		}
+	}
+	return matched
+}
+
+// runeOffsets はバイト位置から文字の位置を引く表を返す。末尾には文字数を入れる。
+func runeOffsets(text string) []int {
+	offsets := make([]int, len(text)+1)
+	i := 0
+	for pos := range text {
+		offsets[pos] = i
+		i++
+	}
+	offsets[len(text)] = i
+	return offsets
+}
+
+// words は text を NFKC で揃えて小文字化し、文字・数字が続く部分ごとに区切る。
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(norm.NFKC.String(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// ngrams は語を 1 文字ずつと 2 文字ずつに切り出す。
+// 1 文字の検索語も索引から引けるよう、2 文字の語に加えて 1 文字の語も並べる。
+func ngrams(word string) []string {
+	runes := []rune(word)
+	tokens := make([]string, 0, 2*len(runes))
+	for i := range runes {
+		tokens = append(tokens, string(runes[i]))
+		if i+1 < len(runes) {
+			tokens = append(tokens, string(runes[i:i+2]))
+		}
+	}
+	return tokens
+}
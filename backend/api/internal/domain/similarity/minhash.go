@@ -0,0 +1,140 @@
+// Package similarity は自由記述の文章どうしの類似度を求める。
+// 永続化や HTTP には依存せず、文字列だけを扱う。
+package similarity
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// DefaultShingleSize は文字 n-gram の長さの既定値。
+// 日本語は単語の区切りがないため、単語ではなく文字単位で切り出す。
+const DefaultShingleSize = 3
+
+// DefaultSignatureSize は MinHash の署名に使うハッシュ関数の数の既定値。
+// 推定した Jaccard 係数の標準誤差はおよそ 1/√n となる。
+const DefaultSignatureSize = 128
+
+// DefaultBandCount は署名を LSH の帯に分ける本数の既定値。
+// 128 個の署名を 4 個ずつ 32 本に分けると、Jaccard 係数 0.8 の組はほぼ確実にいずれかの帯が一致する。
+const DefaultBandCount = 32
+
+// Normalize は比較の前に空白・句読点・記号を取り除き、英字を小文字に揃える。
+func Normalize(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Shingles は正規化した text を size 文字ずつずらして切り出し、重複を除いたハッシュ値の集合を返す。
+// size 文字に満たない文章は全体を 1 つの断片とする。空の場合は nil を返す。
+func Shingles(text string, size int) []uint64 {
+	runes := []rune(Normalize(text))
+	if len(runes) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = DefaultShingleSize
+	}
+	if len(runes) < size {
+		size = len(runes)
+	}
+
+	seen := make(map[uint64]struct{}, len(runes))
+	shingles := make([]uint64, 0, len(runes))
+	for i := 0; i+size <= len(runes); i++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(string(runes[i : i+size])))
+		sum := h.Sum64()
+		if _, ok := seen[sum]; ok {
+			continue
+		}
+		seen[sum] = struct{}{}
+		shingles = append(shingles, sum)
+	}
+	return shingles
+}
+
+// Signature は断片の集合を MinHash で要約したもの。
+type Signature []uint64
+
+// NewSignature は断片の集合から size 個のハッシュ関数の最小値を並べた署名を作る。断片が空の場合は nil を返す。
+func NewSignature(shingles []uint64, size int) Signature {
+	if len(shingles) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = DefaultSignatureSize
+	}
+	sig := make(Signature, size)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	for _, s := range shingles {
+		for i := range sig {
+			if h := mix(s ^ seed(i)); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+	return sig
+}
+
+// Similarity は 2 つの署名から元の集合の Jaccard 係数 (0〜1) を推定する。
+// どちらかが空、または長さが異なる場合は 0 を返す。
+func (s Signature) Similarity(other Signature) float64 {
+	if len(s) == 0 || len(s) != len(other) {
+		return 0
+	}
+	same := 0
+	for i := range s {
+		if s[i] == other[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(s))
+}
+
+// Bands は署名を count 本の帯に分け、帯ごとのハッシュ値を返す (LSH)。
+// 似た文章どうしはいずれかの帯が一致しやすいため、帯が一致するものだけを比べれば全件と比べずに済む。
+// 帯の位置もハッシュに含め、別の帯で同じ値が並んでも一致しないようにする。
+// 署名が空、または count で割り切れない場合は nil を返す。
+func (s Signature) Bands(count int) []uint64 {
+	if count <= 0 {
+		count = DefaultBandCount
+	}
+	if len(s) == 0 || len(s)%count != 0 {
+		return nil
+	}
+	rows := len(s) / count
+	bands := make([]uint64, count)
+	for b := range bands {
+		h := seed(b)
+		for _, v := range s[b*rows : (b+1)*rows] {
+			h = mix(h ^ v)
+		}
+		bands[b] = h
+	}
+	return bands
+}
+
+// seed は i 番目のハッシュ関数を区別する値を返す。
+func seed(i int) uint64 {
+	return mix(uint64(i) + 0x9e3779b97f4a7c15)
+}
+
+// mix は splitmix64 の最終段で 64 ビット値を攪拌する。
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
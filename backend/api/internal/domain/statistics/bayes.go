@@ -0,0 +1,42 @@
+package statistics
+
+// BayesianAverage は件数の少ない平均値を事前平均に引き寄せた推定値を返す。
+// priorWeight は事前平均を何件分のデータとして扱うかを表し、件数が priorWeight と同じとき両者を半々に混ぜる。
+// 1 件だけ満点の店舗が上位を独占するのを防ぐためにランキングで使う。
+func BayesianAverage(mean float64, n int, priorMean, priorWeight float64) float64 {
+	if n <= 0 {
+		return priorMean
+	}
+	if priorWeight <= 0 {
+		return mean
+	}
+	return (priorWeight*priorMean + float64(n)*mean) / (priorWeight + float64(n))
+}
+
+// ShrinkageWeight は BayesianAverage で実データに置かれる重み (0〜1) を返す。
+// 件数が多いほど 1 に近づくため、推定値の信頼度として使える。
+func ShrinkageWeight(n int, priorWeight float64) float64 {
+	if n <= 0 {
+		return 0
+	}
+	if priorWeight <= 0 {
+		return 1
+	}
+	return float64(n) / (float64(n) + priorWeight)
+}
+
+// WeightedMean は件数で重み付けした平均値の平均を返す。件数の合計が 0 の場合は 0 を返す。
+func WeightedMean(means []float64, counts []int) float64 {
+	var sum, total float64
+	for i, m := range means {
+		if i >= len(counts) || counts[i] <= 0 {
+			continue
+		}
+		sum += m * float64(counts[i])
+		total += float64(counts[i])
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
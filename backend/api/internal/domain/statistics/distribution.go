@@ -0,0 +1,127 @@
+// Package statistics はアンケート集計で共有する統計計算を提供する。
+// 永続化や HTTP には依存せず、数値の列だけを扱う。
+package statistics
+
+import (
+	"math"
+	"sort"
+)
+
+// Bucket はヒストグラムの 1 区間を表す。区間は [Lower, Upper) で、最後の区間のみ Upper を含む。
+type Bucket struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// Distribution は数値の分布を要約したもの。Count が 0 の場合は他の値はゼロ値となる。
+type Distribution struct {
+	Count   int
+	Mean    float64
+	Min     float64
+	Max     float64
+	P25     float64
+	Median  float64
+	P75     float64
+	Buckets []Bucket
+}
+
+// Describe は values の要約統計量と、edges を区切りとしたヒストグラムを求める。
+// edges は昇順で 2 つ以上必要で、範囲外の値は最初または最後の区間に含める。
+func Describe(values []float64, edges []float64) Distribution {
+	dist := Distribution{Count: len(values), Buckets: newBuckets(edges)}
+	if len(values) == 0 {
+		return dist
+	}
+
+	sorted := Sorted(values)
+	dist.Mean = Mean(sorted)
+	dist.Min = sorted[0]
+	dist.Max = sorted[len(sorted)-1]
+	dist.P25 = Quantile(sorted, 0.25)
+	dist.Median = Quantile(sorted, 0.5)
+	dist.P75 = Quantile(sorted, 0.75)
+	for _, v := range sorted {
+		if i := bucketIndex(dist.Buckets, v); i >= 0 {
+			dist.Buckets[i].Count++
+		}
+	}
+	return dist
+}
+
+// Sorted は values を昇順に並べ替えたコピーを返す。
+func Sorted(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// Mean は算術平均を返す。空の場合は 0 を返す。
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Quantile は昇順に並んだ sorted の q 分位点 (0〜1) を線形補間で求める。空の場合は 0 を返す。
+func Quantile(sorted []float64, q float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return sorted[0]
+	}
+	if q >= 1 {
+		return sorted[n-1]
+	}
+	pos := q * float64(n-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*frac
+}
+
+// LinearEdges は min から max までを width 刻みで区切った区間の境界を返す。
+// max が width で割り切れない場合は最後の境界を max に揃える。
+func LinearEdges(min, max, width float64) []float64 {
+	if width <= 0 || max <= min {
+		return []float64{min, max}
+	}
+	edges := []float64{}
+	for v := min; v < max; v += width {
+		edges = append(edges, v)
+	}
+	return append(edges, max)
+}
+
+func newBuckets(edges []float64) []Bucket {
+	if len(edges) < 2 {
+		return nil
+	}
+	buckets := make([]Bucket, 0, len(edges)-1)
+	for i := 0; i+1 < len(edges); i++ {
+		buckets = append(buckets, Bucket{Lower: edges[i], Upper: edges[i+1]})
+	}
+	return buckets
+}
+
+func bucketIndex(buckets []Bucket, v float64) int {
+	if len(buckets) == 0 {
+		return -1
+	}
+	if v < buckets[0].Lower {
+		return 0
+	}
+	for i, b := range buckets {
+		if v < b.Upper {
+			return i
+		}
+	}
+	return len(buckets) - 1
+}
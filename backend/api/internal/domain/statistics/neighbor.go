@@ -0,0 +1,42 @@
+package statistics
+
+import (
+	"math"
+	"sort"
+)
+
+// Neighbor は近傍探索で見つかった点の添字と、問い合わせ点からの距離を表す。
+type Neighbor struct {
+	Index    int
+	Distance float64
+}
+
+// Nearest は points のうち query に近い順に最大 k 件を返す。
+// 距離は次元ごとの差に weights を掛けたユークリッド距離で、weights が足りない次元は 1 とする。
+// 距離が等しい点は添字の小さい順に並べる。
+func Nearest(points [][]float64, query []float64, weights []float64, k int) []Neighbor {
+	if k <= 0 || len(points) == 0 {
+		return nil
+	}
+	neighbors := make([]Neighbor, 0, len(points))
+	for i, p := range points {
+		var sum float64
+		for d, q := range query {
+			if d >= len(p) {
+				break
+			}
+			w := 1.0
+			if d < len(weights) {
+				w = weights[d]
+			}
+			diff := (p[d] - q) * w
+			sum += diff * diff
+		}
+		neighbors = append(neighbors, Neighbor{Index: i, Distance: math.Sqrt(sum)})
+	}
+	sort.SliceStable(neighbors, func(i, j int) bool { return neighbors[i].Distance < neighbors[j].Distance })
+	if len(neighbors) > k {
+		neighbors = neighbors[:k]
+	}
+	return neighbors
+}
@@ -0,0 +1,41 @@
+package statistics
+
+import "math"
+
+// madToSigma は正規分布で中央絶対偏差を標準偏差に換算する係数。
+const madToSigma = 1.4826
+
+// RobustSpread は昇順に並んだ sorted の中央値と、外れ値に引きずられにくいばらつきを返す。
+// ばらつきは中央絶対偏差を標準偏差相当に換算した値で、同じ値が多く 0 になる場合は
+// 四分位範囲、平均絶対偏差の順に代わりの見積もりを使う。どれも 0 の場合は 0 を返す。
+func RobustSpread(sorted []float64) (median, spread float64) {
+	if len(sorted) == 0 {
+		return 0, 0
+	}
+	median = Quantile(sorted, 0.5)
+
+	deviations := make([]float64, len(sorted))
+	var sumDeviation float64
+	for i, v := range sorted {
+		deviations[i] = math.Abs(v - median)
+		sumDeviation += deviations[i]
+	}
+	if mad := Quantile(Sorted(deviations), 0.5); mad > 0 {
+		return median, mad * madToSigma
+	}
+	if iqr := Quantile(sorted, 0.75) - Quantile(sorted, 0.25); iqr > 0 {
+		return median, iqr / 1.349
+	}
+	if meanDeviation := sumDeviation / float64(len(sorted)); meanDeviation > 0 {
+		return median, meanDeviation * 1.2533
+	}
+	return median, 0
+}
+
+// RobustZScore は value が中央値からばらつき何個分離れているかを返す。ばらつきが 0 の場合は 0 を返す。
+func RobustZScore(value, median, spread float64) float64 {
+	if spread <= 0 {
+		return 0
+	}
+	return (value - median) / spread
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"time"
+
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+// Comparison は店舗を並べて比較するための集計結果を表す。
+// Stats は保存済みの統計ではなく取得時に承認済みアンケートから集計した値、
+// LatestVisit は承認済みアンケートのうち最新の稼働時期（月初の日時）で、アンケートがない場合は nil。
+type Comparison struct {
+	Store       *Store
+	Stats       store_vo.Stats
+	LatestVisit *time.Time
+}
@@ -8,11 +8,24 @@ import (
 )
 
 // Repo は Store 集約の永続化操作を提供する。
+// FindByID/FindByIDIncludingDeleted は該当がない場合 (nil, nil) を返す。
+// 削除は Store.MarkDeleted の上で Save する論理削除とし、Purge のみがドキュメントを物理削除する。
+// Search は前後のページを取得するカーソルを PageInfo で返す。カーソルの並び順が sort と異なる場合は common_vo.ErrInvalidCursor を返す。
+// FindMatchCandidates は論理削除されていない店舗のうち CandidateFilter に合うものを最大 limit 件返す。
+// 店舗名が一致する店舗を先に並べ、都道府県・業種だけが一致する店舗より優先して枠に含める。
+// FindForComparison は指定した店舗のうち論理削除されていないものを集計結果付きで返す。順序は保証しない。
+// UpdateStats は統計と平均総評のみを部分更新し、該当する店舗がない場合は何もしない。
 type Repo interface {
 	Save(context.Context, *Store) error
 	FindByID(context.Context, store_vo.ID) (*Store, error)
+	FindByIDIncludingDeleted(context.Context, store_vo.ID) (*Store, error)
+	FindDeleted(context.Context, common_vo.Pagination) ([]*Store, int64, error)
 	FindByPrefecture(context.Context, store_vo.Prefecture, common_vo.Pagination) ([]*Store, error)
 	FindByArea(context.Context, store_vo.Area, common_vo.Pagination) ([]*Store, error)
-	Search(context.Context, SearchFilter, common_vo.SortKey, common_vo.Pagination) ([]*Store, int64, error)
-	Delete(context.Context, store_vo.ID) error
+	Search(context.Context, SearchFilter, common_vo.SortKey, common_vo.Pagination) ([]*Store, common_vo.PageInfo, error)
+	FindAll(context.Context) ([]*Store, error)
+	FindMatchCandidates(ctx context.Context, filter CandidateFilter, limit int) ([]*Store, error)
+	FindForComparison(context.Context, []store_vo.ID) ([]Comparison, error)
+	UpdateStats(context.Context, store_vo.ID, store_vo.Stats) error
+	Purge(context.Context, store_vo.ID) error
 }
@@ -1,12 +1,35 @@
 package store
 
-import store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+import (
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
 
 // SearchFilter は管理画面向けの店舗検索条件を表す。
+// Prefectures/Areas/Industries/Genres は複数指定するといずれかに一致する店舗に限定し、空の場合は条件に含めない。
+// NameKeyword は店舗名・支店名の部分一致で、store_vo.SearchKey と同じ正規化をした上で照合する。
+// Rating/SurveyCount/AvgEarning/AvgWaitTime は承認済みアンケートから集計した統計の範囲で、
+// 統計がない（アンケートがない）店舗は範囲を指定した項目に一致しない。
+// HasBusinessHours は営業時間の登録有無で絞り込み、nil の場合は条件に含めない。
 type SearchFilter struct {
-	Prefecture  *store_vo.Prefecture
-	Area        *store_vo.Area
-	Industry    *store_vo.Industry
-	Genre       *store_vo.Genre
-	NameKeyword string
+	Prefectures      []store_vo.Prefecture
+	Areas            []store_vo.Area
+	Industries       []store_vo.Industry
+	Genres           []store_vo.Genre
+	NameKeyword      string
+	Rating           common_vo.Range
+	SurveyCount      common_vo.Range
+	AvgEarning       common_vo.Range
+	AvgWaitTime      common_vo.Range
+	HasBusinessHours *bool
+}
+
+// CandidateFilter は店舗の照合で採点する候補を絞り込む条件を表す。
+// NameGrams のいずれかを store_vo.SearchKey に部分一致で含む店舗と、Prefecture/Industry に一致する店舗を候補とする。
+// NameGrams は textnorm で正規化した店舗名の文字 n-gram を渡す。空の場合は店舗名の条件を使わない。
+// Prefecture/Industry は指定したものがすべて一致する必要があり、どちらも nil の場合はその条件を使わない。
+type CandidateFilter struct {
+	NameGrams  []string
+	Prefecture *store_vo.Prefecture
+	Industry   *store_vo.Industry
 }
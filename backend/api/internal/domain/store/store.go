@@ -7,6 +7,13 @@ import (
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
 )
 
+var (
+	// ErrAlreadyDeleted は削除済みの店舗を削除しようとした場合に返される。
+	ErrAlreadyDeleted = errors.New("この店舗は既に削除されています")
+	// ErrNotDeleted は削除されていない店舗を復元・完全削除しようとした場合に返される。
+	ErrNotDeleted = errors.New("この店舗は削除されていません")
+)
+
 // Store は店舗の集約を表す。
 type Store struct {
 	id            store_vo.ID
@@ -19,6 +26,7 @@ type Store struct {
 	businessHours *store_vo.BusinessHours
 	unitPrice     *store_vo.UnitPrice
 	averageRating store_vo.AverageRating
+	stats         store_vo.Stats
 	createdAt     common_vo.Timestamp
 	updatedAt     common_vo.Timestamp
 	deletedAt     *common_vo.Timestamp
@@ -80,6 +88,14 @@ func WithGenre(genre store_vo.Genre) Option {
 	}
 }
 
+// WithStats はアンケートから集計した統計を設定する。
+func WithStats(stats store_vo.Stats) Option {
+	return func(s *Store) error {
+		s.stats = stats
+		return nil
+	}
+}
+
 // WithCreatedAt は作成日時を設定する。
 func WithCreatedAt(ts common_vo.Timestamp) Option {
 	return func(s *Store) error {
@@ -160,6 +176,9 @@ func (s *Store) validate() error {
 	if !s.averageRating.IsZero() && !s.averageRating.Validate() {
 		return errors.New("平均総評の入力値が不正です")
 	}
+	if !s.stats.Validate() {
+		return errors.New("店舗統計の入力値が不正です")
+	}
 	if s.createdAt.IsZero() {
 		s.createdAt = common_vo.NowTimestamp()
 	}
@@ -175,6 +194,39 @@ func (s *Store) validate() error {
 	return nil
 }
 
+// MarkDeleted は店舗を論理削除する。ドキュメントは残り、復元できる。
+func (s *Store) MarkDeleted(at common_vo.Timestamp) error {
+	if s.deletedAt != nil {
+		return ErrAlreadyDeleted
+	}
+	t := at
+	s.deletedAt = &t
+	s.updatedAt = at
+	return nil
+}
+
+// Restore は論理削除を取り消す。
+func (s *Store) Restore(at common_vo.Timestamp) error {
+	if s.deletedAt == nil {
+		return ErrNotDeleted
+	}
+	s.deletedAt = nil
+	s.updatedAt = at
+	return nil
+}
+
+// ApplyStats はアンケートから再集計した統計を反映し、平均総評も統計に合わせる。
+// 統計は派生データのため更新日時は変更しない。
+func (s *Store) ApplyStats(stats store_vo.Stats) {
+	s.stats = stats
+	s.averageRating = stats.AverageRating()
+}
+
+// IsDeleted は論理削除済みかどうかを返す。
+func (s *Store) IsDeleted() bool {
+	return s.deletedAt != nil
+}
+
 // ID は店舗IDを返す。
 func (s *Store) ID() store_vo.ID {
 	return s.id
@@ -225,6 +277,11 @@ func (s *Store) AverageRating() store_vo.AverageRating {
 	return s.averageRating
 }
 
+// Stats はアンケートから集計した統計を返す。
+func (s *Store) Stats() store_vo.Stats {
+	return s.stats
+}
+
 // CreatedAt は作成日時を返す。
 func (s *Store) CreatedAt() common_vo.Timestamp {
 	return s.createdAt
@@ -0,0 +1,24 @@
+package submission
+
+import (
+	"context"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	submission_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/submission"
+)
+
+// Repo は Submission 集約の永続化操作を提供する。
+// FindByID は該当がない場合 (nil, nil) を返す。
+// SaveIfPending は保存済みの投稿が確認待ちの場合のみ置き換え、置き換えたかを返す。
+// 変換・却下の状態遷移に使い、同じ投稿を同時に処理した場合に一方だけが成功するようにする。
+type Repo interface {
+	Save(context.Context, *Submission) error
+	SaveIfPending(context.Context, *Submission) (bool, error)
+	FindByID(context.Context, submission_vo.ID) (*Submission, error)
+	Find(context.Context, Filter, common_vo.Pagination) ([]*Submission, int64, error)
+}
+
+// Filter は投稿一覧の検索条件を表す。nil の項目は条件に含めない。
+type Filter struct {
+	Status *submission_vo.Status
+}
@@ -0,0 +1,218 @@
+package submission
+
+import (
+	"errors"
+	"strings"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	submission_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/submission"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// ErrNotPending は確認待ち以外の投稿を変換・却下しようとした場合に返される。
+var ErrNotPending = errors.New("この投稿は処理済みです")
+
+// Payload は一般ユーザーが送信したアンケート内容をそのまま保持する。
+// 入力値の検証はアンケートへ変換する時点で行うため、ここでは加工しない。
+type Payload struct {
+	StoreName              string
+	BranchName             string
+	Prefecture             string
+	Industry               string
+	StoreID                string
+	VisitedPeriod          string
+	WorkType               string
+	Age                    int
+	SpecScore              int
+	WaitTimeHours          int
+	AverageEarning         int
+	Rating                 float64
+	CustomerComment        *string
+	StaffComment           *string
+	WorkEnvironmentComment *string
+	EtcComment             *string
+	CastBack               *string
+	EmailAddress           *string
+	ImageURLs              []string
+}
+
+// Submission は一般ユーザーからのアンケート投稿を表す集約。
+// 管理者が確認してアンケートへ変換するか、却下するまで pending のまま保持される。
+type Submission struct {
+	id              submission_vo.ID
+	payload         Payload
+	clientIP        string
+	status          submission_vo.Status
+	surveyID        *survey_vo.ID
+	processedBy     string
+	processedAt     *common_vo.Timestamp
+	rejectionReason string
+	receivedAt      common_vo.Timestamp
+}
+
+// Option は Submission 生成時のオプションを表す。
+type Option func(*Submission) error
+
+// WithClientIP は送信元 IP アドレスを設定する。
+func WithClientIP(ip string) Option {
+	return func(s *Submission) error {
+		s.clientIP = strings.TrimSpace(ip)
+		return nil
+	}
+}
+
+// WithStatus はステータスを設定する。未指定の場合は pending となる。
+func WithStatus(status submission_vo.Status) Option {
+	return func(s *Submission) error {
+		s.status = status
+		return nil
+	}
+}
+
+// WithSurveyID は変換先のアンケート ID を設定する。
+func WithSurveyID(id survey_vo.ID) Option {
+	return func(s *Submission) error {
+		v := id
+		s.surveyID = &v
+		return nil
+	}
+}
+
+// WithProcessed は変換・却下した管理者と日時を設定する。
+func WithProcessed(actor string, at common_vo.Timestamp) Option {
+	return func(s *Submission) error {
+		t := at
+		s.processedBy = strings.TrimSpace(actor)
+		s.processedAt = &t
+		return nil
+	}
+}
+
+// WithRejectionReason は却下理由を設定する。
+func WithRejectionReason(reason string) Option {
+	return func(s *Submission) error {
+		s.rejectionReason = strings.TrimSpace(reason)
+		return nil
+	}
+}
+
+// WithReceivedAt は受信日時を設定する。未指定の場合は現在時刻となる。
+func WithReceivedAt(ts common_vo.Timestamp) Option {
+	return func(s *Submission) error {
+		s.receivedAt = ts
+		return nil
+	}
+}
+
+// NewSubmission は投稿エンティティを生成する。
+func NewSubmission(id submission_vo.ID, payload Payload, opts ...Option) (*Submission, error) {
+	s := &Submission{
+		id:      id,
+		payload: payload,
+	}
+
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Submission) validate() error {
+	if !s.id.Validate() {
+		return errors.New("投稿IDの入力値が不正です")
+	}
+	if s.status.IsZero() {
+		s.status, _ = submission_vo.NewStatus(submission_vo.StatusPending)
+	}
+	if !s.status.Validate() {
+		return errors.New("投稿ステータスの入力値が不正です")
+	}
+	if s.receivedAt.IsZero() {
+		s.receivedAt = common_vo.NowTimestamp()
+	}
+	if !s.receivedAt.Validate() {
+		return errors.New("受信日時の入力値が不正です")
+	}
+	if s.processedAt != nil && !s.processedAt.Validate() {
+		return errors.New("処理日時の入力値が不正です")
+	}
+	return nil
+}
+
+// MarkConverted は投稿をアンケートへ変換済みにする。
+func (s *Submission) MarkConverted(surveyID survey_vo.ID, actor string, at common_vo.Timestamp) error {
+	if !s.status.IsPending() {
+		return ErrNotPending
+	}
+	id := surveyID
+	t := at
+	s.status, _ = submission_vo.NewStatus(submission_vo.StatusConverted)
+	s.surveyID = &id
+	s.processedBy = strings.TrimSpace(actor)
+	s.processedAt = &t
+	return nil
+}
+
+// Reject は投稿を却下する。
+func (s *Submission) Reject(actor, reason string, at common_vo.Timestamp) error {
+	if !s.status.IsPending() {
+		return ErrNotPending
+	}
+	t := at
+	s.status, _ = submission_vo.NewStatus(submission_vo.StatusRejected)
+	s.processedBy = strings.TrimSpace(actor)
+	s.processedAt = &t
+	s.rejectionReason = strings.TrimSpace(reason)
+	return nil
+}
+
+// ID は投稿 ID を返す。
+func (s *Submission) ID() submission_vo.ID {
+	return s.id
+}
+
+// Payload は送信された内容を返す。
+func (s *Submission) Payload() Payload {
+	return s.payload
+}
+
+// ClientIP は送信元 IP アドレスを返す。
+func (s *Submission) ClientIP() string {
+	return s.clientIP
+}
+
+// Status は処理状況を返す。
+func (s *Submission) Status() submission_vo.Status {
+	return s.status
+}
+
+// SurveyID は変換先のアンケート ID を返す（未変換の場合は nil）。
+func (s *Submission) SurveyID() *survey_vo.ID {
+	return s.surveyID
+}
+
+// ProcessedBy は変換・却下した管理者の subject を返す。
+func (s *Submission) ProcessedBy() string {
+	return s.processedBy
+}
+
+// ProcessedAt は変換・却下した日時を返す（未処理の場合は nil）。
+func (s *Submission) ProcessedAt() *common_vo.Timestamp {
+	return s.processedAt
+}
+
+// RejectionReason は却下理由を返す。
+func (s *Submission) RejectionReason() string {
+	return s.rejectionReason
+}
+
+// ReceivedAt は受信日時を返す。
+func (s *Submission) ReceivedAt() common_vo.Timestamp {
+	return s.receivedAt
+}
@@ -0,0 +1,140 @@
+package survey
+
+import (
+	"errors"
+	"strings"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// ErrNoAnomalies は外れ値の指摘がないアンケートで確認しようとした場合に返される。
+var ErrNoAnomalies = errors.New("このアンケートに外れ値の指摘はありません")
+
+// AnomalyField は外れ値を判定する数値の回答項目を表す。
+type AnomalyField string
+
+const (
+	AnomalyFieldAverageEarning AnomalyField = "averageEarning"
+	AnomalyFieldWaitTimeHours  AnomalyField = "waitTimeHours"
+)
+
+// AnomalyReference は外れ値の判定で比べた分布を表す。
+type AnomalyReference string
+
+const (
+	AnomalyReferenceStore    AnomalyReference = "store"
+	AnomalyReferenceIndustry AnomalyReference = "industry"
+)
+
+// Anomaly は回答の値が比較した分布から大きく外れていることを表す。
+// Lower/Upper は外れ値とみなさない範囲、Score は中央値からの外れ具合（ロバスト z スコア）。
+type Anomaly struct {
+	Field     AnomalyField
+	Reference AnomalyReference
+	Value     float64
+	Median    float64
+	Lower     float64
+	Upper     float64
+	Score     float64
+}
+
+// AnswerFilter は外れ値の判定で比べる回答の範囲を表す。nil の項目は条件に含めない。
+// ExcludeID には判定対象のアンケート自身を指定し、更新前の値と比べないようにする。
+type AnswerFilter struct {
+	StoreID   *store_vo.ID
+	Industry  *store_vo.Industry
+	ExcludeID *survey_vo.ID
+}
+
+// NumericAnswers は数値の回答を項目ごとに並べたもの。
+type NumericAnswers struct {
+	AverageEarnings []float64
+	WaitTimeHours   []float64
+}
+
+// WithAnomalies は外れ値の指摘を設定する。既存アンケートを読み込む場合にのみ指定する。
+func WithAnomalies(anomalies []Anomaly) Option {
+	return func(s *Survey) error {
+		s.anomalies = append([]Anomaly(nil), anomalies...)
+		return nil
+	}
+}
+
+// WithAnomalyConfirmation は外れ値の指摘を確認した管理者と日時を設定する。
+func WithAnomalyConfirmation(confirmedBy string, at common_vo.Timestamp) Option {
+	return func(s *Survey) error {
+		t := at
+		s.anomaliesConfirmedBy = strings.TrimSpace(confirmedBy)
+		s.anomaliesConfirmedAt = &t
+		return nil
+	}
+}
+
+// FlagAnomalies は外れ値の指摘を置き換える。
+// 確認済みの指摘と項目・値が同じ場合は確認を引き継ぎ、回答が変わった場合は確認を取り消す。
+func (s *Survey) FlagAnomalies(anomalies []Anomaly) {
+	if !sameAnomalyValues(s.anomalies, anomalies) {
+		s.anomaliesConfirmedBy = ""
+		s.anomaliesConfirmedAt = nil
+	}
+	s.anomalies = append([]Anomaly(nil), anomalies...)
+}
+
+// ConfirmAnomalies は外れ値の指摘を管理者が確認し、値が正しいものとして集計に含める。
+func (s *Survey) ConfirmAnomalies(confirmedBy string, at common_vo.Timestamp) error {
+	if len(s.anomalies) == 0 {
+		return ErrNoAnomalies
+	}
+	t := at
+	s.anomaliesConfirmedBy = strings.TrimSpace(confirmedBy)
+	s.anomaliesConfirmedAt = &t
+	s.updatedAt = at
+	return nil
+}
+
+// HasPendingAnomalies は未確認の外れ値の指摘があるかを返す。該当するアンケートは統計・ランキングの集計から外す。
+func (s *Survey) HasPendingAnomalies() bool {
+	return len(s.anomalies) > 0 && s.anomaliesConfirmedAt == nil
+}
+
+// Anomalies は外れ値の指摘を返す。
+func (s *Survey) Anomalies() []Anomaly {
+	return append([]Anomaly(nil), s.anomalies...)
+}
+
+// AnomaliesConfirmedBy は外れ値の指摘を確認した管理者を返す。
+func (s *Survey) AnomaliesConfirmedBy() string {
+	return s.anomaliesConfirmedBy
+}
+
+// AnomaliesConfirmedAt は外れ値の指摘を確認した日時を返す。
+func (s *Survey) AnomaliesConfirmedAt() *common_vo.Timestamp {
+	return s.anomaliesConfirmedAt
+}
+
+// sameAnomalyValues は指摘された項目と値の組み合わせが一致するかを返す。
+// 比較した分布の中央値などはアンケートが増えるたびに変わるため比べない。
+func sameAnomalyValues(a, b []Anomaly) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	values := func(anomalies []Anomaly) map[AnomalyField]float64 {
+		m := make(map[AnomalyField]float64, len(anomalies))
+		for _, x := range anomalies {
+			m[x.Field] = x.Value
+		}
+		return m
+	}
+	av, bv := values(a), values(b)
+	if len(av) != len(bv) {
+		return false
+	}
+	for field, v := range av {
+		if w, ok := bv[field]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
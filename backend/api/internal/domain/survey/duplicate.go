@@ -0,0 +1,73 @@
+package survey
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sngm3741/makoto-club-services/api/internal/domain/similarity"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// DuplicateReason は重複の疑いがあると判断した理由を表す。
+type DuplicateReason string
+
+const (
+	// DuplicateReasonEmail は同じメールアドレスで回答されていることを表す。
+	DuplicateReasonEmail DuplicateReason = "sameEmail"
+	// DuplicateReasonAnswers は同じ店舗・稼働時期で数値の回答がすべて一致することを表す。
+	DuplicateReasonAnswers DuplicateReason = "sameAnswers"
+	// DuplicateReasonText はコメントの文章がほぼ同じであることを表す。
+	DuplicateReasonText DuplicateReason = "similarText"
+)
+
+// minSignatureTextLength は文章の類似度を比べるのに必要な正規化後の文字数。
+// 「特になし」のような短いコメントはどのアンケートとも一致しやすいため比べない。
+const minSignatureTextLength = 20
+
+// DuplicateCandidate は重複の疑いがあるアンケートと、その理由を表す。
+// TextSimilarity はコメントの推定 Jaccard 係数で、比べられない場合は 0 となる。
+type DuplicateCandidate struct {
+	Survey         *Survey
+	Reasons        []DuplicateReason
+	TextSimilarity float64
+}
+
+// DuplicateQuery は重複の候補を探す条件。作成日時が From〜To のアンケートのうち、
+// Keys (DuplicateKeys) のいずれかを持つもの、または店舗と稼働時期が同じものを対象とする。
+type DuplicateQuery struct {
+	StoreID       store_vo.ID
+	VisitedPeriod survey_vo.VisitedPeriod
+	Keys          []string
+	From          time.Time
+	To            time.Time
+}
+
+// CommentSignature は自由記述のコメントをつなげた文章の MinHash 署名を返す。
+// 文章が短く比べる意味がない場合は nil を返す。
+func (s *Survey) CommentSignature() similarity.Signature {
+	list := s.comments()
+	texts := make([]string, 0, len(list))
+	for _, c := range list {
+		texts = append(texts, c.text)
+	}
+	text := strings.Join(texts, "\n")
+	if len([]rune(similarity.Normalize(text))) < minSignatureTextLength {
+		return nil
+	}
+	return similarity.NewSignature(similarity.Shingles(text, similarity.DefaultShingleSize), similarity.DefaultSignatureSize)
+}
+
+// DuplicateKeys は重複の候補を索引から探すためのキーを返す。
+// 小文字に揃えたメールアドレスと、コメントの署名を LSH の帯に分けたハッシュ値からなり、保存時にドキュメントへ持たせる。
+func (s *Survey) DuplicateKeys() []string {
+	var keys []string
+	if !s.emailAddress.IsZero() {
+		keys = append(keys, "email:"+strings.ToLower(s.emailAddress.Value()))
+	}
+	for _, band := range s.CommentSignature().Bands(similarity.DefaultBandCount) {
+		keys = append(keys, fmt.Sprintf("text:%016x", band))
+	}
+	return keys
+}
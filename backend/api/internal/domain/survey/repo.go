@@ -9,18 +9,73 @@ import (
 )
 
 // Repo は Survey 集約の永続化操作を提供する。
+// FindByID/FindPublishedByID/FindByIDIncludingDeleted は該当がない場合 (nil, nil) を返す。
+// 削除は Survey.MarkDeleted の上で Save する論理削除とし、Purge のみがドキュメントを物理削除する。
+// FindByStore/FindByPrefecture は公開 API 向けのため承認済みのアンケートのみを返す。
+// StoreStats/AllStoreStats/MonthlyTrends/EarningSamples/NumericAnswers も同様に、論理削除されていない承認済みのアンケートのみを集計する。
+// これらの集計では、外れ値の指摘が未確認のアンケート (Survey.HasPendingAnomalies) も除く。
+// FindAdmin は前後のページを取得するカーソルを PageInfo で返す。カーソルの並び順が sort と異なる場合や、
+// 全文検索 (AdminFilter.Text) とカーソルを併用した場合は common_vo.ErrInvalidCursor を返す。全文検索の結果にはカーソルを発行しない。
+// FindDuplicateCandidates は重複の検出に使うため、審査状況に関わらず論理削除されていないアンケートから DuplicateQuery に合うものを返す。
+// IncrementHelpful は「参考になった」の件数を delta だけ原子的に増減する。件数が負になる減算は行わない。
+// CountByStore は店舗の削除可否の判定に使うため、論理削除済みのアンケートも数える。
+// CountLiveByStore は店舗の完全削除の可否の判定に使い、論理削除されていないアンケートのみを数える。
+// *ByStore 系・StoreSnapshot 系の一括操作は店舗の変更・削除・復元・付け替えに合わせてアンケートを整合させるために使い、対象件数を返す。
 type Repo interface {
 	Save(context.Context, *Survey) error
 	FindByID(context.Context, survey_vo.ID) (*Survey, error)
+	FindPublishedByID(context.Context, survey_vo.ID) (*Survey, error)
+	FindByIDIncludingDeleted(context.Context, survey_vo.ID) (*Survey, error)
+	FindDeleted(context.Context, common_vo.Pagination) ([]*Survey, int64, error)
 	FindByStore(context.Context, store_vo.ID, common_vo.SortKey, common_vo.Pagination) ([]*Survey, int64, error)
 	FindByPrefecture(context.Context, store_vo.Prefecture, common_vo.SortKey, common_vo.Pagination) ([]*Survey, int64, error)
-	FindAdmin(context.Context, AdminFilter, common_vo.SortKey, common_vo.Pagination) ([]*Survey, int64, error)
-	Delete(context.Context, survey_vo.ID) error
+	FindAdmin(context.Context, AdminFilter, common_vo.SortKey, common_vo.Pagination) ([]*Survey, common_vo.PageInfo, error)
+	FindDuplicateCandidates(context.Context, DuplicateQuery) ([]*Survey, error)
+	Purge(context.Context, survey_vo.ID) error
+	IncrementHelpful(ctx context.Context, id survey_vo.ID, delta int) error
+
+	CountByStore(context.Context, store_vo.ID) (int64, error)
+	CountLiveByStore(context.Context, store_vo.ID) (int64, error)
+	SoftDeleteByStore(ctx context.Context, storeID store_vo.ID, at common_vo.Timestamp) (int64, error)
+	RestoreByStore(ctx context.Context, storeID store_vo.ID, deletedAt, at common_vo.Timestamp) (int64, error)
+	ReassignStore(ctx context.Context, from store_vo.ID, to StoreSnapshot, at common_vo.Timestamp) (int64, error)
+	PurgeDeletedByStore(context.Context, store_vo.ID) (int64, error)
+	SyncStoreSnapshot(ctx context.Context, snapshot StoreSnapshot, at common_vo.Timestamp) (int64, error)
+	CountStoreSnapshotDrift(context.Context, StoreSnapshot) (int64, error)
+
+	StoreStats(context.Context, store_vo.ID) (store_vo.Stats, error)
+	AllStoreStats(context.Context) (map[string]store_vo.Stats, error)
+	MonthlyTrends(context.Context, TrendFilter) ([]PeriodAggregate, error)
+	EarningSamples(context.Context) ([]EarningSample, error)
+	NumericAnswers(context.Context, AnswerFilter) (NumericAnswers, error)
 }
 
-// AdminFilter は管理画面での検索条件を表す。
+// AdminFilter は管理画面での検索条件を表す。nil の項目は条件に含めない。
+// PublishedOnly は公開 API から検索する場合に指定し、承認済みのアンケートに限定する。
+// Keyword は店舗名・支店名の部分一致で、store_vo.SearchKey と同じ正規化をした上で照合する。
+// PendingAnomalies は外れ値の指摘が未確認のアンケートに限定する。
+// Text は自由記述のコメントを全文検索する語句で、空白で区切った語をすべて含むアンケートに限定する。
+// Text を指定した場合は、並び順より先に検索語との関連度の高い順に並べる。
+// Age/SpecScore/AverageEarning/WaitTimeHours/Rating は回答の値の範囲、WorkTypes はいずれかに一致する勤務形態を表す。
+// VisitedFrom/VisitedTo は稼働時期の範囲で、両端の月を含む。ゼロ値の端は制限しない。
+// HasImages は画像の添付有無で絞り込み、nil の場合は条件に含めない。
 type AdminFilter struct {
-	Prefecture *store_vo.Prefecture
-	Industry   *store_vo.Industry
-	Keyword    string
+	StoreID          *store_vo.ID
+	Prefecture       *store_vo.Prefecture
+	Industry         *store_vo.Industry
+	Keyword          string
+	Text             string
+	Status           *survey_vo.Status
+	PublishedOnly    bool
+	PendingAnomalies bool
+
+	Age            common_vo.Range
+	SpecScore      common_vo.Range
+	WorkTypes      []survey_vo.WorkType
+	AverageEarning common_vo.Range
+	WaitTimeHours  common_vo.Range
+	Rating         common_vo.Range
+	VisitedFrom    survey_vo.VisitedPeriod
+	VisitedTo      survey_vo.VisitedPeriod
+	HasImages      *bool
 }
@@ -0,0 +1,18 @@
+package survey
+
+import (
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// EarningSample は稼ぎの推定に使うアンケート 1 件分の属性を表す。
+// 本文やメールアドレスなどは含めず、推定に必要な項目だけを保持する。
+type EarningSample struct {
+	StoreID        store_vo.ID
+	Prefecture     store_vo.Prefecture
+	Industry       store_vo.Industry
+	WorkType       survey_vo.WorkType
+	Age            survey_vo.Age
+	SpecScore      survey_vo.SpecScore
+	AverageEarning survey_vo.AverageEarning
+}
@@ -0,0 +1,68 @@
+package survey
+
+import (
+	"github.com/sngm3741/makoto-club-services/api/internal/domain/fulltext"
+)
+
+// CommentField は全文検索の対象とする自由記述の項目を表す。
+type CommentField string
+
+const (
+	CommentFieldCustomer        CommentField = "customerComment"
+	CommentFieldStaff           CommentField = "staffComment"
+	CommentFieldWorkEnvironment CommentField = "workEnvironmentComment"
+	CommentFieldEtc             CommentField = "etcComment"
+)
+
+// CommentSnippet は検索語に一致したコメントの抜粋を表す。
+type CommentSnippet struct {
+	Field    CommentField
+	Segments []fulltext.Segment
+}
+
+// comment は自由記述の項目と本文の組。
+type comment struct {
+	field CommentField
+	text  string
+}
+
+// comments は入力された自由記述を項目の並び順に返す。
+func (s *Survey) comments() []comment {
+	var list []comment
+	if c := s.customerComment; c != nil {
+		list = append(list, comment{field: CommentFieldCustomer, text: c.Value()})
+	}
+	if c := s.staffComment; c != nil {
+		list = append(list, comment{field: CommentFieldStaff, text: c.Value()})
+	}
+	if c := s.workEnvironmentComment; c != nil {
+		list = append(list, comment{field: CommentFieldWorkEnvironment, text: c.Value()})
+	}
+	if c := s.etcComment; c != nil {
+		list = append(list, comment{field: CommentFieldEtc, text: c.Value()})
+	}
+	return list
+}
+
+// CommentSearchIndex は自由記述を全文検索するための索引用の文字列を返す。
+func (s *Survey) CommentSearchIndex() string {
+	list := s.comments()
+	texts := make([]string, 0, len(list))
+	for _, c := range list {
+		texts = append(texts, c.text)
+	}
+	return fulltext.IndexText(texts...)
+}
+
+// CommentSnippets は自由記述のうち query に一致する部分を含む項目の抜粋を返す。
+func (s *Survey) CommentSnippets(query string) []CommentSnippet {
+	var snippets []CommentSnippet
+	for _, c := range s.comments() {
+		segments, ok := fulltext.Highlight(c.text, query, fulltext.DefaultSnippetLength)
+		if !ok {
+			continue
+		}
+		snippets = append(snippets, CommentSnippet{Field: c.field, Segments: segments})
+	}
+	return snippets
+}
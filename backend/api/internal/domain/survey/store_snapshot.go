@@ -0,0 +1,17 @@
+package survey
+
+import (
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+// StoreSnapshot はアンケートに複製して保持する店舗情報を表す。
+// 店舗の付け替えや店舗情報の変更をアンケートへ反映する際に使う。
+type StoreSnapshot struct {
+	ID         store_vo.ID
+	Name       store_vo.Name
+	Branch     *store_vo.BranchName
+	Prefecture store_vo.Prefecture
+	Area       *store_vo.Area
+	Industry   store_vo.Industry
+	Genre      *store_vo.Genre
+}
@@ -2,12 +2,20 @@ package survey
 
 import (
 	"errors"
+	"strings"
 
 	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
 	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
 )
 
+var (
+	// ErrAlreadyDeleted は削除済みのアンケートを削除しようとした場合に返される。
+	ErrAlreadyDeleted = errors.New("このアンケートは既に削除されています")
+	// ErrNotDeleted は削除されていないアンケートを復元・完全削除しようとした場合に返される。
+	ErrNotDeleted = errors.New("このアンケートは削除されていません")
+)
+
 // Survey はアンケートの集約を表す。
 type Survey struct {
 	id        survey_vo.ID
@@ -36,6 +44,17 @@ type Survey struct {
 	emailAddress           survey_vo.EmailAddress
 	imageURLs              survey_vo.ImageURLs
 
+	status          survey_vo.Status
+	reviewedBy      string
+	reviewedAt      *common_vo.Timestamp
+	rejectionReason string
+
+	helpfulCount int
+
+	anomalies            []Anomaly
+	anomaliesConfirmedBy string
+	anomaliesConfirmedAt *common_vo.Timestamp
+
 	createdAt common_vo.Timestamp
 	updatedAt common_vo.Timestamp
 	deletedAt *common_vo.Timestamp
@@ -132,6 +151,41 @@ func WithImageURLs(urls survey_vo.ImageURLs) Option {
 	}
 }
 
+// WithStatus は掲載ステータスを設定する。未指定の場合は approved となる。
+func WithStatus(status survey_vo.Status) Option {
+	return func(s *Survey) error {
+		s.status = status
+		return nil
+	}
+}
+
+// WithReview は承認・非承認を行った管理者と日時を設定する。
+func WithReview(reviewer string, at common_vo.Timestamp) Option {
+	return func(s *Survey) error {
+		t := at
+		s.reviewedBy = strings.TrimSpace(reviewer)
+		s.reviewedAt = &t
+		return nil
+	}
+}
+
+// WithRejectionReason は非承認の理由を設定する。
+func WithRejectionReason(reason string) Option {
+	return func(s *Survey) error {
+		s.rejectionReason = strings.TrimSpace(reason)
+		return nil
+	}
+}
+
+// WithHelpfulCount は「参考になった」の件数を設定する。件数は投票時にリポジトリ側で加算されるため、
+// 既存アンケートを読み込む場合にのみ指定する。保存時には書き込まれない。
+func WithHelpfulCount(count int) Option {
+	return func(s *Survey) error {
+		s.helpfulCount = count
+		return nil
+	}
+}
+
 // WithSurveyTimestamps は作成・更新日時を設定する。
 func WithSurveyTimestamps(created, updated common_vo.Timestamp) Option {
 	return func(s *Survey) error {
@@ -261,6 +315,21 @@ func (s *Survey) validate() error {
 	if !s.imageURLs.Validate() {
 		return errors.New("画像URLの入力値が不正です")
 	}
+	if s.status.IsZero() {
+		s.status, _ = survey_vo.NewStatus(survey_vo.StatusApproved)
+	}
+	if !s.status.Validate() {
+		return errors.New("掲載ステータスの入力値が不正です")
+	}
+	if s.reviewedAt != nil && !s.reviewedAt.Validate() {
+		return errors.New("審査日時の入力値が不正です")
+	}
+	if s.helpfulCount < 0 {
+		return errors.New("参考になった件数が不正です")
+	}
+	if s.anomaliesConfirmedAt != nil && !s.anomaliesConfirmedAt.Validate() {
+		return errors.New("外れ値の確認日時が不正です")
+	}
 	if s.createdAt.IsZero() {
 		s.createdAt = common_vo.NowTimestamp()
 	}
@@ -276,6 +345,52 @@ func (s *Survey) validate() error {
 	return nil
 }
 
+// Approve はアンケートを承認し、公開対象にする。
+func (s *Survey) Approve(reviewer string, at common_vo.Timestamp) {
+	t := at
+	s.status, _ = survey_vo.NewStatus(survey_vo.StatusApproved)
+	s.reviewedBy = strings.TrimSpace(reviewer)
+	s.reviewedAt = &t
+	s.rejectionReason = ""
+	s.updatedAt = at
+}
+
+// Reject はアンケートを非承認とし、公開対象から外す。
+func (s *Survey) Reject(reviewer, reason string, at common_vo.Timestamp) {
+	t := at
+	s.status, _ = survey_vo.NewStatus(survey_vo.StatusRejected)
+	s.reviewedBy = strings.TrimSpace(reviewer)
+	s.reviewedAt = &t
+	s.rejectionReason = strings.TrimSpace(reason)
+	s.updatedAt = at
+}
+
+// MarkDeleted はアンケートを論理削除する。ドキュメントは残り、復元できる。
+func (s *Survey) MarkDeleted(at common_vo.Timestamp) error {
+	if s.deletedAt != nil {
+		return ErrAlreadyDeleted
+	}
+	t := at
+	s.deletedAt = &t
+	s.updatedAt = at
+	return nil
+}
+
+// Restore は論理削除を取り消す。
+func (s *Survey) Restore(at common_vo.Timestamp) error {
+	if s.deletedAt == nil {
+		return ErrNotDeleted
+	}
+	s.deletedAt = nil
+	s.updatedAt = at
+	return nil
+}
+
+// IsDeleted は論理削除済みかどうかを返す。
+func (s *Survey) IsDeleted() bool {
+	return s.deletedAt != nil
+}
+
 // Equals はアンケートIDで同一性を判定する。
 func (s *Survey) Equals(other *Survey) bool {
 	if other == nil {
@@ -394,6 +509,31 @@ func (s *Survey) ImageURLs() survey_vo.ImageURLs {
 	return s.imageURLs
 }
 
+// Status は掲載ステータスを返す。
+func (s *Survey) Status() survey_vo.Status {
+	return s.status
+}
+
+// ReviewedBy は承認・非承認を行った管理者の subject を返す。
+func (s *Survey) ReviewedBy() string {
+	return s.reviewedBy
+}
+
+// ReviewedAt は承認・非承認の日時を返す（未審査の場合は nil）。
+func (s *Survey) ReviewedAt() *common_vo.Timestamp {
+	return s.reviewedAt
+}
+
+// RejectionReason は非承認の理由を返す。
+func (s *Survey) RejectionReason() string {
+	return s.rejectionReason
+}
+
+// HelpfulCount は「参考になった」の件数を返す。
+func (s *Survey) HelpfulCount() int {
+	return s.helpfulCount
+}
+
 // CreatedAt は作成日時を返す。
 func (s *Survey) CreatedAt() common_vo.Timestamp {
 	return s.createdAt
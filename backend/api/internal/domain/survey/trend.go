@@ -0,0 +1,26 @@
+package survey
+
+import (
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// TrendFilter は稼働時期ごとの推移を集計する対象を表す。nil の項目は条件に含めない。
+// From/To は稼働時期の範囲で、両端の月を含む。
+type TrendFilter struct {
+	StoreID    *store_vo.ID
+	Prefecture *store_vo.Prefecture
+	Area       *store_vo.Area
+	Industry   *store_vo.Industry
+	From       survey_vo.VisitedPeriod
+	To         survey_vo.VisitedPeriod
+}
+
+// PeriodAggregate は稼働時期 1 か月分のアンケートの集計結果を表す。
+type PeriodAggregate struct {
+	Period      survey_vo.VisitedPeriod
+	SurveyCount int
+	AvgEarning  float64
+	AvgRating   float64
+	AvgWaitTime float64
+}
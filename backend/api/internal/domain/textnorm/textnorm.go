@@ -0,0 +1,60 @@
+// Package textnorm は店舗名などの表記揺れを吸収して照合するための正規化を扱う。
+// 「ﾃﾞﾘﾍﾙ」と「デリヘル」、「でりへる」と「デリヘル」のように、見た目や読みが同じ表記を同じ文字列に揃える。
+// 永続化や HTTP には依存せず、文字列だけを扱う。
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 平仮名とそれに対応する片仮名の符号位置の差。
+const kanaOffset = 'ア' - 'あ'
+
+// smallKana は小書きの仮名を通常の大きさの仮名に揃える対応表。
+// 「ディ」と「デイ」、「ヶ」と「ケ」のような表記揺れを同一視するため。
+var smallKana = map[rune]rune{
+	'ァ': 'ア', 'ィ': 'イ', 'ゥ': 'ウ', 'ェ': 'エ', 'ォ': 'オ',
+	'ッ': 'ツ', 'ャ': 'ヤ', 'ュ': 'ユ', 'ョ': 'ヨ', 'ヮ': 'ワ',
+	'ヵ': 'カ', 'ヶ': 'ケ',
+}
+
+// Normalize は照合用に文字列を正規化する。
+// 全角/半角を NFKC で揃えて小文字化し、平仮名を片仮名に、小書きの仮名を通常の仮名に揃える。
+// 長音記号・空白・句読点・記号は取り除く。
+func Normalize(value string) string {
+	value = strings.ToLower(norm.NFKC.String(value))
+	var b strings.Builder
+	b.Grow(len(value))
+	for _, r := range value {
+		if isLongVowelMark(r) {
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			continue
+		}
+		b.WriteRune(foldKana(r))
+	}
+	return b.String()
+}
+
+// foldKana は平仮名を片仮名に、小書きの仮名を通常の大きさの仮名に変換する。
+func foldKana(r rune) rune {
+	switch {
+	case 'ぁ' <= r && r <= 'ゖ', r == 'ゝ', r == 'ゞ':
+		// 「ゝ」「ゞ」も対応する片仮名の踊り字「ヽ」「ヾ」に揃える。
+		r += kanaOffset
+	}
+	if large, ok := smallKana[r]; ok {
+		return large
+	}
+	return r
+}
+
+// isLongVowelMark は長音記号かを返す。半角の「ｰ」は NFKC で「ー」に揃っている。
+// 長音記号は文字として扱われるため、記号とは別に取り除く。
+func isLongVowelMark(r rune) bool {
+	return r == 'ー'
+}
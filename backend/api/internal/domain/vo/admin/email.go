@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	emailRegexp = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+	// ErrEmptyEmail はメールアドレスが未入力の場合に返される。
+	ErrEmptyEmail = errors.New("メールアドレスは必須です")
+	// ErrInvalidEmail はメールアドレスの形式が不正な場合に返される。
+	ErrInvalidEmail = errors.New("メールアドレスの形式が不正です")
+	// ErrEmailTooLong はメールアドレスが長すぎる場合に返される。
+	ErrEmailTooLong = errors.New("メールアドレスは256文字以内で入力してください")
+)
+
+const maxEmailLength = 256
+
+// Email は管理者のログインIDとして使うメールアドレスを表す。
+// 大文字小文字の揺れでアカウントが分かれないよう、小文字に正規化して保持する。
+type Email struct {
+	value string
+}
+
+// NewEmail は入力を正規化・検証し、値オブジェクトを生成する。
+func NewEmail(input string) (Email, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	if value == "" {
+		return Email{}, ErrEmptyEmail
+	}
+	if len(value) > maxEmailLength {
+		return Email{}, ErrEmailTooLong
+	}
+	if !emailRegexp.MatchString(value) {
+		return Email{}, ErrInvalidEmail
+	}
+	return Email{value: value}, nil
+}
+
+// String は内部値を返す。
+func (e Email) String() string {
+	return e.value
+}
+
+// Value は内部値を文字列として返す。
+func (e Email) Value() string {
+	return e.value
+}
+
+// Equals は別の Email と一致するか判定する。
+func (e Email) Equals(other Email) bool {
+	return e.value == other.value
+}
+
+// Validate は形式が正しいかを判定する。
+func (e Email) Validate() bool {
+	return e.value != "" && len(e.value) <= maxEmailLength && emailRegexp.MatchString(e.value)
+}
+
+// IsZero は未設定かどうかを判定する。
+func (e Email) IsZero() bool {
+	return e.value == ""
+}
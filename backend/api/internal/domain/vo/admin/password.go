@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// MinPasswordLength はパスワードの最小文字数（バイト数）。
+	MinPasswordLength = 10
+	// MaxPasswordLength は bcrypt が扱える最大バイト数。
+	MaxPasswordLength = 72
+
+	passwordCost = 12
+)
+
+var (
+	// ErrPasswordTooShort はパスワードが短すぎる場合に返される。
+	ErrPasswordTooShort = errors.New("パスワードは10文字以上で入力してください")
+	// ErrPasswordTooLong はパスワードが bcrypt の上限を超える場合に返される。
+	ErrPasswordTooLong = errors.New("パスワードは72バイト以内で入力してください")
+	// ErrInvalidPasswordHash は保存済みハッシュの形式が不正な場合に返される。
+	ErrInvalidPasswordHash = errors.New("パスワードハッシュの形式が不正です")
+)
+
+// PasswordHash は bcrypt でハッシュ化済みのパスワードを表す値オブジェクト。
+// 平文は保持せず、照合は Matches で行う。
+type PasswordHash struct {
+	value string
+}
+
+// HashPassword は平文パスワードの長さを検証し、bcrypt でハッシュ化する。
+func HashPassword(plain string) (PasswordHash, error) {
+	if len(plain) < MinPasswordLength {
+		return PasswordHash{}, ErrPasswordTooShort
+	}
+	if len(plain) > MaxPasswordLength {
+		return PasswordHash{}, ErrPasswordTooLong
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(plain), passwordCost)
+	if err != nil {
+		return PasswordHash{}, err
+	}
+	return PasswordHash{value: string(hashed)}, nil
+}
+
+// NewPasswordHash は永続化済みのハッシュ文字列から値オブジェクトを復元する。
+func NewPasswordHash(hash string) (PasswordHash, error) {
+	if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+		return PasswordHash{}, ErrInvalidPasswordHash
+	}
+	return PasswordHash{value: hash}, nil
+}
+
+// Matches は平文パスワードがハッシュと一致するか判定する。
+func (p PasswordHash) Matches(plain string) bool {
+	if p.value == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(p.value), []byte(plain)) == nil
+}
+
+// Value はハッシュ文字列を返す。
+func (p PasswordHash) Value() string {
+	return p.value
+}
+
+// Validate は bcrypt ハッシュとして解釈できるかを判定する。
+func (p PasswordHash) Validate() bool {
+	_, err := bcrypt.Cost([]byte(p.value))
+	return err == nil
+}
+
+// IsZero は未設定かどうかを判定する。
+func (p PasswordHash) IsZero() bool {
+	return p.value == ""
+}
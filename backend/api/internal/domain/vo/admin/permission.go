@@ -0,0 +1,30 @@
+package admin
+
+// Permission は管理 API の操作単位の権限を表す。
+// どのロールがどの権限を持つかは role.go の rolePermissions で定義する。
+type Permission string
+
+const (
+	// PermissionStoreRead は店舗の閲覧権限。
+	PermissionStoreRead Permission = "stores:read"
+	// PermissionStoreWrite は店舗の作成・更新権限。
+	PermissionStoreWrite Permission = "stores:write"
+	// PermissionStoreDelete は店舗の削除権限。
+	PermissionStoreDelete Permission = "stores:delete"
+	// PermissionSurveyRead はアンケートの閲覧権限。
+	PermissionSurveyRead Permission = "surveys:read"
+	// PermissionSurveyWrite はアンケートの作成・更新権限。
+	PermissionSurveyWrite Permission = "surveys:write"
+	// PermissionSurveyDelete はアンケートの削除権限。
+	PermissionSurveyDelete Permission = "surveys:delete"
+	// PermissionSurveyModerate はアンケートの承認・非承認の権限。
+	PermissionSurveyModerate Permission = "surveys:moderate"
+	// PermissionTrashManage はゴミ箱の閲覧と論理削除からの復元の権限。
+	PermissionTrashManage Permission = "trash:manage"
+	// PermissionTrashPurge はゴミ箱からの物理削除の権限。取り消せないため他の権限とは分けて付与する。
+	PermissionTrashPurge Permission = "trash:purge"
+	// PermissionAdminManage は管理者アカウントの管理権限。
+	PermissionAdminManage Permission = "admins:manage"
+	// PermissionAuditRead は監査ログの閲覧権限。
+	PermissionAuditRead Permission = "audit:read"
+)
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmptyRole はロールが未指定の場合に返される。
+var ErrEmptyRole = errors.New("ロールは必須です")
+
+// ErrInvalidRole は定義されていないロールが指定された場合に返される。
+var ErrInvalidRole = errors.New("存在しないロールが指定されました")
+
+const (
+	// RoleOwner は管理者アカウントの管理や削除を含むすべての操作が可能。
+	RoleOwner = "owner"
+	// RoleEditor は店舗・アンケートの編集と承認が可能だが、削除はできない。
+	RoleEditor = "editor"
+	// RoleViewer は閲覧のみ可能。
+	RoleViewer = "viewer"
+)
+
+var rolePermissions = map[string]map[Permission]struct{}{
+	RoleOwner: {
+		PermissionStoreRead:      {},
+		PermissionStoreWrite:     {},
+		PermissionStoreDelete:    {},
+		PermissionSurveyRead:     {},
+		PermissionSurveyWrite:    {},
+		PermissionSurveyDelete:   {},
+		PermissionSurveyModerate: {},
+		PermissionTrashManage:    {},
+		PermissionTrashPurge:     {},
+		PermissionAdminManage:    {},
+		PermissionAuditRead:      {},
+	},
+	RoleEditor: {
+		PermissionStoreRead:      {},
+		PermissionStoreWrite:     {},
+		PermissionSurveyRead:     {},
+		PermissionSurveyWrite:    {},
+		PermissionSurveyModerate: {},
+		PermissionAuditRead:      {},
+	},
+	RoleViewer: {
+		PermissionStoreRead:  {},
+		PermissionSurveyRead: {},
+	},
+}
+
+// Role は管理者の権限グループを表す値オブジェクト。
+type Role struct {
+	value string
+}
+
+// NewRole はロールを検証し、値オブジェクトを生成する。
+func NewRole(input string) (Role, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	if value == "" {
+		return Role{}, ErrEmptyRole
+	}
+	if _, ok := rolePermissions[value]; !ok {
+		return Role{}, ErrInvalidRole
+	}
+	return Role{value: value}, nil
+}
+
+// Can はロールが指定の権限を持つか判定する。
+func (r Role) Can(permission Permission) bool {
+	_, ok := rolePermissions[r.value][permission]
+	return ok
+}
+
+// String は内部値を返す。
+func (r Role) String() string {
+	return r.value
+}
+
+// Value は内部値を文字列として返す。
+func (r Role) Value() string {
+	return r.value
+}
+
+// Equals は別の Role と一致するか判定する。
+func (r Role) Equals(other Role) bool {
+	return r.value == other.value
+}
+
+// Validate は許可されたロールかどうかを判定する。
+func (r Role) Validate() bool {
+	_, ok := rolePermissions[r.value]
+	return ok
+}
+
+// IsZero は未設定かどうかを判定する。
+func (r Role) IsZero() bool {
+	return r.value == ""
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor はカーソルの形式が不正な場合や、並び順と一致しない場合に返される。
+var ErrInvalidCursor = errors.New("カーソルの形式が不正です")
+
+// Cursor は一覧の続きを取得する位置を表す値オブジェクト。
+// 並び替えに使う項目の値と ID を並べた Values の位置を境に、その次（Backward の場合は手前）から取得する。
+// Values の組み立て方と読み方はリポジトリが決め、API の利用者には String の不透明な文字列として渡す。
+type Cursor struct {
+	sortKey  string
+	backward bool
+	values   []string
+}
+
+// cursorPayload は Cursor を文字列にする際の形式。
+type cursorPayload struct {
+	SortKey  string   `json:"s"`
+	Backward bool     `json:"b,omitempty"`
+	Values   []string `json:"v"`
+}
+
+// NewCursor は並び順と、境となる項目の値から Cursor を生成する。
+func NewCursor(sortKey SortKey, backward bool, values ...string) Cursor {
+	return Cursor{
+		sortKey:  sortKey.Value(),
+		backward: backward,
+		values:   append([]string(nil), values...),
+	}
+}
+
+// ParseCursor は String で文字列にしたカーソルを読み取る。
+func ParseCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	c := Cursor{sortKey: payload.SortKey, backward: payload.Backward, values: payload.Values}
+	if !c.Validate() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// String はカーソルを URL のクエリにそのまま使える不透明な文字列にする。ゼロ値の場合は空文字を返す。
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw, _ := json.Marshal(cursorPayload{SortKey: c.sortKey, Backward: c.backward, Values: c.values})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// SortKey はカーソルを発行した一覧の並び順を返す。
+func (c Cursor) SortKey() SortKey {
+	return SortKey{value: c.sortKey}
+}
+
+// Backward は境より手前（前のページ）を取得するカーソルかを返す。
+func (c Cursor) Backward() bool {
+	return c.backward
+}
+
+// Values は境となる項目の値を返す。
+func (c Cursor) Values() []string {
+	return append([]string(nil), c.values...)
+}
+
+// Equals は別の Cursor と一致するか判定する。
+func (c Cursor) Equals(other Cursor) bool {
+	return c.String() == other.String()
+}
+
+// Validate は並び順が許可されたキーで、境となる値を持つかを判定する。
+func (c Cursor) Validate() bool {
+	return c.SortKey().Validate() && len(c.values) > 0
+}
+
+// IsZero は未設定であるかどうかを判定する。
+func (c Cursor) IsZero() bool {
+	return c.sortKey == "" && len(c.values) == 0
+}
+
+// PageInfo は一覧を取得した結果のページ情報を表す。
+// Total は条件に一致する件数で、カーソルで取得した場合は数えずに nil とする。
+// Next/Prev は次・前のページを取得するカーソルで、該当するページがない場合はゼロ値となる。
+type PageInfo struct {
+	Total *int64
+	Next  Cursor
+	Prev  Cursor
+}
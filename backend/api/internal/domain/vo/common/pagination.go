@@ -10,9 +10,11 @@ const (
 )
 
 // Pagination は一覧取得時のページと件数を表す値オブジェクト。
+// page/limit で位置を指定するほか、NewCursorPagination でカーソルの位置から limit 件を指定できる。
 type Pagination struct {
-	page  int
-	limit int
+	page   int
+	limit  int
+	cursor Cursor
 }
 
 // NewPagination はページ・件数を正規化して Pagination を返す。
@@ -32,7 +34,15 @@ func NewPagination(page, limit int) Pagination {
 	}
 }
 
-// Page は現在のページ番号を返す。
+// NewCursorPagination はカーソルの位置から limit 件を取得する Pagination を返す。ページ番号は持たない。
+func NewCursorPagination(cursor Cursor, limit int) Pagination {
+	p := NewPagination(DefaultPage, limit)
+	p.page = 0
+	p.cursor = cursor
+	return p
+}
+
+// Page は現在のページ番号を返す。カーソルで指定した場合は 0。
 func (p Pagination) Page() int {
 	return p.page
 }
@@ -42,13 +52,29 @@ func (p Pagination) Limit() int {
 	return p.limit
 }
 
-// Offset は Skip 件数を返す。
+// Cursor は取得を始める位置のカーソルを返す。page/limit で指定した場合はゼロ値。
+func (p Pagination) Cursor() Cursor {
+	return p.cursor
+}
+
+// UsesCursor はカーソルで位置を指定しているかを返す。
+func (p Pagination) UsesCursor() bool {
+	return !p.cursor.IsZero()
+}
+
+// Offset は Skip 件数を返す。カーソルで指定した場合は 0。
 func (p Pagination) Offset() int {
+	if p.UsesCursor() {
+		return 0
+	}
 	return (p.page - 1) * p.limit
 }
 
 // Validate はページとリミットが有効値かどうかを判定する。
 func (p Pagination) Validate() bool {
+	if p.UsesCursor() {
+		return p.cursor.Validate() && p.limit > 0 && p.limit <= MaxLimit
+	}
 	return p.page >= DefaultPage && p.limit > 0 && p.limit <= MaxLimit
 }
 
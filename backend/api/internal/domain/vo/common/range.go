@@ -0,0 +1,73 @@
+package common
+
+import "errors"
+
+// ErrInvalidRange は範囲の下限が上限を超えている場合に返される。
+var ErrInvalidRange = errors.New("範囲の下限は上限以下で指定してください")
+
+// Range は検索条件に使う数値の範囲を表す値オブジェクト。
+// 下限・上限はどちらも範囲に含み、nil の端は制限しない。両端とも nil の場合は条件なしとなる。
+type Range struct {
+	min *float64
+	max *float64
+}
+
+// NewRange は下限・上限から Range を生成する。両方指定した場合は下限が上限以下であることを検証する。
+func NewRange(min, max *float64) (Range, error) {
+	r := Range{}
+	if min != nil {
+		v := *min
+		r.min = &v
+	}
+	if max != nil {
+		v := *max
+		r.max = &v
+	}
+	if !r.Validate() {
+		return Range{}, ErrInvalidRange
+	}
+	return r, nil
+}
+
+// Min は下限を返す。制限しない場合は nil。
+func (r Range) Min() *float64 {
+	return r.min
+}
+
+// Max は上限を返す。制限しない場合は nil。
+func (r Range) Max() *float64 {
+	return r.max
+}
+
+// Contains は value が範囲に含まれるかを返す。
+func (r Range) Contains(value float64) bool {
+	if r.min != nil && value < *r.min {
+		return false
+	}
+	if r.max != nil && value > *r.max {
+		return false
+	}
+	return true
+}
+
+// Equals は別の Range と一致するか判定する。
+func (r Range) Equals(other Range) bool {
+	return equalBound(r.min, other.min) && equalBound(r.max, other.max)
+}
+
+// Validate は下限が上限以下かどうかを判定する。
+func (r Range) Validate() bool {
+	return r.min == nil || r.max == nil || *r.min <= *r.max
+}
+
+// IsZero は下限・上限とも制限しないかを返す。
+func (r Range) IsZero() bool {
+	return r.min == nil && r.max == nil
+}
+
+func equalBound(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
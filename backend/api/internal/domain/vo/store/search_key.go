@@ -0,0 +1,14 @@
+package store
+
+import "github.com/sngm3741/makoto-club-services/api/internal/domain/textnorm"
+
+// SearchKey は店舗名と支店名をつなげて正規化した、店舗名の部分一致検索に使うキーを返す。
+// 検索語も textnorm.Normalize で正規化してから照合することで、全角/半角や平仮名/片仮名の違いを吸収する。
+// 店舗名と支店名をつなげておくため、「店舗名 支店名」のような検索語でも一致する。
+func SearchKey(name Name, branch *BranchName) string {
+	value := name.Value()
+	if branch != nil {
+		value += branch.Value()
+	}
+	return textnorm.Normalize(value)
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidStats は統計値が不正な場合に返される。
+var ErrInvalidStats = errors.New("店舗統計の値が不正です")
+
+// Stats は承認済みアンケートから集計した店舗の統計を表す。
+// アンケートが 1 件もない場合は件数 0 で、平均値と最終投稿日時は nil となる。
+// helpfulCount は集計対象のアンケートに付いた「参考になった」の合計で、店舗一覧の並び替えに使う。
+type Stats struct {
+	surveyCount    int
+	helpfulCount   int
+	avgRating      *float64
+	avgEarning     *float64
+	avgWaitTime    *float64
+	lastSurveyedAt *time.Time
+}
+
+// NewStats は集計結果を検証して Stats を生成する。件数が 0 の場合は平均値などを無視する。
+func NewStats(surveyCount, helpfulCount int, avgRating, avgEarning, avgWaitTime *float64, lastSurveyedAt *time.Time) (Stats, error) {
+	if surveyCount < 0 || helpfulCount < 0 {
+		return Stats{}, ErrInvalidStats
+	}
+	if surveyCount == 0 {
+		return Stats{}, nil
+	}
+	if avgRating != nil && (*avgRating < MinAverageRating || *avgRating > MaxAverageRating) {
+		return Stats{}, ErrInvalidStats
+	}
+	stats := Stats{
+		surveyCount:  surveyCount,
+		helpfulCount: helpfulCount,
+		avgRating:    copyFloat(avgRating),
+		avgEarning:   copyFloat(avgEarning),
+		avgWaitTime:  copyFloat(avgWaitTime),
+	}
+	if lastSurveyedAt != nil {
+		t := lastSurveyedAt.UTC()
+		stats.lastSurveyedAt = &t
+	}
+	return stats, nil
+}
+
+// SurveyCount は集計対象のアンケート件数を返す。
+func (s Stats) SurveyCount() int {
+	return s.surveyCount
+}
+
+// HelpfulCount は集計対象のアンケートに付いた「参考になった」の合計を返す。
+func (s Stats) HelpfulCount() int {
+	return s.helpfulCount
+}
+
+// AvgRating は総評の平均を返す（アンケートがない場合は nil）。
+func (s Stats) AvgRating() *float64 {
+	return copyFloat(s.avgRating)
+}
+
+// AvgEarning は平均稼ぎの平均を返す（アンケートがない場合は nil）。
+func (s Stats) AvgEarning() *float64 {
+	return copyFloat(s.avgEarning)
+}
+
+// AvgWaitTime は待機時間の平均を返す（アンケートがない場合は nil）。
+func (s Stats) AvgWaitTime() *float64 {
+	return copyFloat(s.avgWaitTime)
+}
+
+// LastSurveyedAt は最新のアンケートの登録日時を返す（アンケートがない場合は nil）。
+func (s Stats) LastSurveyedAt() *time.Time {
+	if s.lastSurveyedAt == nil {
+		return nil
+	}
+	t := *s.lastSurveyedAt
+	return &t
+}
+
+// AverageRating は総評の平均を店舗の平均総評として返す。アンケートがない場合はゼロ値となる。
+func (s Stats) AverageRating() AverageRating {
+	if s.avgRating == nil {
+		return AverageRating{}
+	}
+	rating, err := NewAverageRating(*s.avgRating)
+	if err != nil {
+		return AverageRating{}
+	}
+	return rating
+}
+
+// Validate は件数と平均総評が範囲内かどうかを判定する。
+func (s Stats) Validate() bool {
+	if s.surveyCount < 0 || s.helpfulCount < 0 {
+		return false
+	}
+	return s.avgRating == nil || (*s.avgRating >= MinAverageRating && *s.avgRating <= MaxAverageRating)
+}
+
+// IsZero はアンケートが 1 件もない状態かどうかを判定する。
+func (s Stats) IsZero() bool {
+	return s.surveyCount == 0
+}
+
+func copyFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	value := *v
+	return &value
+}
@@ -0,0 +1,69 @@
+package submission
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidStatus は定義されていない投稿ステータスが指定された場合に返される。
+var ErrInvalidStatus = errors.New("存在しない投稿ステータスが指定されました")
+
+const (
+	// StatusPending は管理者の確認待ち。
+	StatusPending = "pending"
+	// StatusConverted はアンケートとして登録済み。
+	StatusConverted = "converted"
+	// StatusRejected は登録しないと判断されたもの。
+	StatusRejected = "rejected"
+)
+
+// Status は一般投稿の処理状況を表す値オブジェクト。
+type Status struct {
+	value string
+}
+
+// NewStatus はステータスを検証し、値オブジェクトを生成する。
+func NewStatus(input string) (Status, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	switch value {
+	case StatusPending, StatusConverted, StatusRejected:
+		return Status{value: value}, nil
+	default:
+		return Status{}, ErrInvalidStatus
+	}
+}
+
+// String は内部値を返す。
+func (s Status) String() string {
+	return s.value
+}
+
+// Value は内部値を文字列として返す。
+func (s Status) Value() string {
+	return s.value
+}
+
+// Equals は別の Status と一致するか判定する。
+func (s Status) Equals(other Status) bool {
+	return s.value == other.value
+}
+
+// Validate は定義済みのステータスかどうかを判定する。
+func (s Status) Validate() bool {
+	switch s.value {
+	case StatusPending, StatusConverted, StatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsPending は確認待ちかどうかを判定する。
+func (s Status) IsPending() bool {
+	return s.value == StatusPending
+}
+
+// IsZero は未設定かどうかを判定する。
+func (s Status) IsZero() bool {
+	return s.value == ""
+}
@@ -0,0 +1,75 @@
+package survey
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmptyStatus は掲載ステータスが未指定の場合に返される。
+var ErrEmptyStatus = errors.New("掲載ステータスは必須です")
+
+// ErrInvalidStatus は定義されていない掲載ステータスが指定された場合に返される。
+var ErrInvalidStatus = errors.New("存在しない掲載ステータスが指定されました")
+
+const (
+	// StatusPending は管理者の承認待ち。公開 API には表示しない。
+	StatusPending = "pending"
+	// StatusApproved は承認済み。status を持たない既存ドキュメントもこの扱いとなる。
+	StatusApproved = "approved"
+	// StatusRejected は非承認。公開 API には表示しない。
+	StatusRejected = "rejected"
+)
+
+var allowedStatuses = map[string]struct{}{
+	StatusPending:  {},
+	StatusApproved: {},
+	StatusRejected: {},
+}
+
+// Status はアンケートの掲載可否を表す値オブジェクト。
+type Status struct {
+	value string
+}
+
+// NewStatus は掲載ステータスを検証し、値オブジェクトを生成する。
+func NewStatus(input string) (Status, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	if value == "" {
+		return Status{}, ErrEmptyStatus
+	}
+	if _, ok := allowedStatuses[value]; !ok {
+		return Status{}, ErrInvalidStatus
+	}
+	return Status{value: value}, nil
+}
+
+// String は内部値を返す。
+func (s Status) String() string {
+	return s.value
+}
+
+// Value は内部値を文字列として返す。
+func (s Status) Value() string {
+	return s.value
+}
+
+// Equals は別の Status と一致するか判定する。
+func (s Status) Equals(other Status) bool {
+	return s.value == other.value
+}
+
+// Validate は許可されたステータスかどうかを判定する。
+func (s Status) Validate() bool {
+	_, ok := allowedStatuses[s.value]
+	return ok
+}
+
+// IsPublished は公開 API に表示してよいかを判定する。
+func (s Status) IsPublished() bool {
+	return s.value == StatusApproved
+}
+
+// IsZero は未設定かどうかを判定する。
+func (s Status) IsZero() bool {
+	return s.value == ""
+}
@@ -55,6 +55,11 @@ func (p VisitedPeriod) Month() time.Month {
 	return p.value.Month()
 }
 
+// Equals は別の VisitedPeriod と同じ年月か判定する。
+func (p VisitedPeriod) Equals(other VisitedPeriod) bool {
+	return p.value.Equal(other.value)
+}
+
 // Validate は値が設定されているかを判定する。
 func (p VisitedPeriod) Validate() bool {
 	return !p.value.IsZero()
@@ -0,0 +1,15 @@
+package vote
+
+import (
+	"context"
+
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// Repo は投票の永続化操作を提供する。
+// 同じアンケートに対して、IP アドレスか端末トークンのハッシュが一致する投票は 1 件しか保存しない。
+// Add は新たに保存した場合、Remove は削除した場合に true を返し、呼び出し側はその場合のみ件数を増減する。
+type Repo interface {
+	Add(context.Context, *Vote) (bool, error)
+	Remove(ctx context.Context, surveyID survey_vo.ID, voter Voter) (bool, error)
+}
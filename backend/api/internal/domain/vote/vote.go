@@ -0,0 +1,133 @@
+// Package vote はアンケートへの「参考になった」投票を扱う。
+// 投票者はログインしないため、IP アドレスと端末トークンからそれぞれ作ったハッシュで同一クライアントを判定する。
+package vote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// ErrVoterUnknown は投票者を識別する情報が 1 つもない場合に返される。
+var ErrVoterUnknown = errors.New("投票者を識別できません")
+
+// VoterHash は投票者を識別するハッシュ値。IP アドレスなどの生の値は保存しない。
+type VoterHash string
+
+// Value はハッシュ値を 16 進文字列で返す。
+func (h VoterHash) Value() string {
+	return string(h)
+}
+
+// Voter は IP アドレスと端末トークンそれぞれから作った投票者ハッシュの組。
+// 端末トークンはクライアントが自由に変えられるため、どちらか一方でも既存の投票と一致すれば同じ投票者とみなす。
+type Voter struct {
+	ip     VoterHash
+	device VoterHash
+}
+
+// NewVoter は salt・IP アドレス・端末トークンから投票者ハッシュを生成する。
+// どちらも任意だが、両方とも未指定の場合は ErrVoterUnknown を返す。
+func NewVoter(salt, ip, deviceToken string) (Voter, error) {
+	ip = strings.TrimSpace(ip)
+	deviceToken = strings.TrimSpace(deviceToken)
+	if ip == "" && deviceToken == "" {
+		return Voter{}, ErrVoterUnknown
+	}
+	var v Voter
+	if ip != "" {
+		v.ip = hashVoter(salt, "ip", ip)
+	}
+	if deviceToken != "" {
+		v.device = hashVoter(salt, "device", deviceToken)
+	}
+	return v, nil
+}
+
+// hashVoter は値の種類を含めてハッシュ化し、IP アドレスと同じ文字列の端末トークンが同じハッシュにならないようにする。
+func hashVoter(salt, kind, value string) VoterHash {
+	// 区切り文字を挟み、値の境界をずらした組み合わせが同じハッシュにならないようにする。
+	sum := sha256.Sum256([]byte(salt + "\x00" + kind + "\x00" + value))
+	return VoterHash(hex.EncodeToString(sum[:]))
+}
+
+// IPHash は IP アドレスのハッシュを返す。IP アドレスが不明な場合は空となる。
+func (v Voter) IPHash() VoterHash {
+	return v.ip
+}
+
+// DeviceHash は端末トークンのハッシュを返す。端末トークンが未指定の場合は空となる。
+func (v Voter) DeviceHash() VoterHash {
+	return v.device
+}
+
+// IsZero は投票者を識別するハッシュが 1 つもない場合に true を返す。
+func (v Voter) IsZero() bool {
+	return v.ip == "" && v.device == ""
+}
+
+// Vote はアンケート 1 件に対する 1 クライアントの投票を表す。
+type Vote struct {
+	surveyID  survey_vo.ID
+	voter     Voter
+	createdAt common_vo.Timestamp
+}
+
+// Option は Vote 生成時のオプションを表す。
+type Option func(*Vote) error
+
+// WithCreatedAt は投票日時を設定する。未指定の場合は現在時刻となる。
+func WithCreatedAt(ts common_vo.Timestamp) Option {
+	return func(v *Vote) error {
+		v.createdAt = ts
+		return nil
+	}
+}
+
+// NewVote は投票を生成する。
+func NewVote(surveyID survey_vo.ID, voter Voter, opts ...Option) (*Vote, error) {
+	v := &Vote{surveyID: surveyID, voter: voter}
+
+	for _, opt := range opts {
+		if err := opt(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := v.validate(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *Vote) validate() error {
+	if !v.surveyID.Validate() {
+		return errors.New("アンケートIDの入力値が不正です")
+	}
+	if v.voter.IsZero() {
+		return ErrVoterUnknown
+	}
+	if v.createdAt.IsZero() {
+		v.createdAt = common_vo.NowTimestamp()
+	}
+	return nil
+}
+
+// SurveyID は投票先のアンケートIDを返す。
+func (v *Vote) SurveyID() survey_vo.ID {
+	return v.surveyID
+}
+
+// Voter は投票者ハッシュの組を返す。
+func (v *Vote) Voter() Voter {
+	return v.voter
+}
+
+// CreatedAt は投票日時を返す。
+func (v *Vote) CreatedAt() common_vo.Timestamp {
+	return v.createdAt
+}
@@ -0,0 +1,163 @@
+// Package auth は管理 API で利用する JWT の発行・検証処理を提供する。
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// AlgorithmHS256 は共有シークレットによる HMAC 署名。
+	AlgorithmHS256 = "HS256"
+	// AlgorithmRS256 は RSA 公開鍵による署名。
+	AlgorithmRS256 = "RS256"
+
+	// TokenUseAccess は API 呼び出しに使うアクセストークンを表す。
+	TokenUseAccess = "access"
+	// TokenUseRefresh はアクセストークン再発行専用のリフレッシュトークンを表す。
+	TokenUseRefresh = "refresh"
+)
+
+var (
+	// ErrInvalidToken は署名・形式・有効期限などの検証に失敗した場合に返される。
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenNotAccepted は署名は正しいが、発行者や対象 API が一致しない場合に返される。
+	ErrTokenNotAccepted = errors.New("token is not accepted by this API")
+)
+
+// Config は JWT の発行・検証に必要な設定をまとめた構造体。
+// Issuer/Audience が空の場合はその項目を検証しない。
+// PrivateKeyPEM は RS256 でトークンを発行する場合のみ必要。
+type Config struct {
+	Algorithm     string
+	Secret        string
+	PublicKeyPEM  string
+	PrivateKeyPEM string
+	Issuer        string
+	Audience      string
+	ClockSkew     time.Duration
+	AccessTTL     time.Duration
+	RefreshTTL    time.Duration
+}
+
+// CanSign はトークンを発行するための鍵が設定されているかを返す。
+// RS256 で公開鍵のみを設定した検証専用の構成では false となる。
+func (c Config) CanSign() bool {
+	switch strings.ToUpper(strings.TrimSpace(c.Algorithm)) {
+	case "", AlgorithmHS256:
+		return strings.TrimSpace(c.Secret) != ""
+	case AlgorithmRS256:
+		return strings.TrimSpace(c.PrivateKeyPEM) != ""
+	default:
+		return false
+	}
+}
+
+// Claims は管理 API が受け付けるトークンのクレーム。
+// TokenUse が空のトークンは外部発行のアクセストークンとして扱う。
+// Role はアクセストークンにのみ含まれ、リフレッシュ時に最新のロールで再発行される。
+type Claims struct {
+	jwt.RegisteredClaims
+	TokenUse string `json:"tokenUse,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+// Verifier は Bearer トークンの署名とクレームを検証する。
+type Verifier struct {
+	key    interface{}
+	parser *jwt.Parser
+}
+
+// NewVerifier は設定値から鍵を読み込み、Verifier を生成する。
+// 鍵が不足している・読み込めない場合はエラーを返す。
+func NewVerifier(cfg Config) (*Verifier, error) {
+	algorithm := strings.ToUpper(strings.TrimSpace(cfg.Algorithm))
+	if algorithm == "" {
+		algorithm = AlgorithmHS256
+	}
+
+	var key interface{}
+	switch algorithm {
+	case AlgorithmHS256:
+		if strings.TrimSpace(cfg.Secret) == "" {
+			return nil, errors.New("auth: HS256 requires a secret")
+		}
+		key = []byte(cfg.Secret)
+	case AlgorithmRS256:
+		if strings.TrimSpace(cfg.PublicKeyPEM) == "" {
+			return nil, errors.New("auth: RS256 requires a public key")
+		}
+		pub, err := jwt.ParseRSAPublicKeyFromPEM([]byte(cfg.PublicKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("auth: failed to parse RSA public key: %w", err)
+		}
+		key = pub
+	default:
+		return nil, fmt.Errorf("auth: unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(cfg.ClockSkew),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{
+		key:    key,
+		parser: jwt.NewParser(opts...),
+	}, nil
+}
+
+// Verify はアクセストークンを検証し、クレームを返す。
+// 発行者/対象 API の不一致は ErrTokenNotAccepted、それ以外の失敗は ErrInvalidToken でラップする。
+func (v *Verifier) Verify(raw string) (*Claims, error) {
+	claims, err := v.parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenUse != "" && claims.TokenUse != TokenUseAccess {
+		return nil, fmt.Errorf("%w: not an access token", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// VerifyRefresh はリフレッシュトークンを検証し、クレームを返す。
+func (v *Verifier) VerifyRefresh(raw string) (*Claims, error) {
+	claims, err := v.parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenUse != TokenUseRefresh {
+		return nil, fmt.Errorf("%w: not a refresh token", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+// parse は署名と登録済みクレームを検証する共通処理。
+func (v *Verifier) parse(raw string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := v.parser.ParseWithClaims(raw, claims, func(*jwt.Token) (interface{}, error) {
+		return v.key, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenInvalidIssuer) || errors.Is(err, jwt.ErrTokenInvalidAudience) {
+			return nil, fmt.Errorf("%w: %v", ErrTokenNotAccepted, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if strings.TrimSpace(claims.Subject) == "" {
+		return nil, fmt.Errorf("%w: subject is empty", ErrInvalidToken)
+	}
+	return claims, nil
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// Signer は管理者向けのアクセストークン/リフレッシュトークンを発行する。
+// 発行したトークンは同じ Config から生成した Verifier で検証できる。
+type Signer struct {
+	method     jwt.SigningMethod
+	key        interface{}
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	verifier   *Verifier
+}
+
+// NewSigner は署名鍵を読み込み、Signer を生成する。
+// RS256 の場合は PrivateKeyPEM が必須となる。
+func NewSigner(cfg Config) (*Signer, error) {
+	verifier, err := NewVerifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		method jwt.SigningMethod
+		key    interface{}
+	)
+	switch strings.ToUpper(strings.TrimSpace(cfg.Algorithm)) {
+	case "", AlgorithmHS256:
+		method = jwt.SigningMethodHS256
+		key = []byte(cfg.Secret)
+	case AlgorithmRS256:
+		if strings.TrimSpace(cfg.PrivateKeyPEM) == "" {
+			return nil, errors.New("auth: RS256 requires a private key to issue tokens")
+		}
+		priv, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.PrivateKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("auth: failed to parse RSA private key: %w", err)
+		}
+		method = jwt.SigningMethodRS256
+		key = priv
+	default:
+		return nil, fmt.Errorf("auth: unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	accessTTL := cfg.AccessTTL
+	if accessTTL <= 0 {
+		accessTTL = defaultAccessTTL
+	}
+	refreshTTL := cfg.RefreshTTL
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTTL
+	}
+
+	return &Signer{
+		method:     method,
+		key:        key,
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		verifier:   verifier,
+	}, nil
+}
+
+// IssueAccessToken は subject 向けのロール付きアクセストークンと有効期限を返す。
+func (s *Signer) IssueAccessToken(subject, role string) (string, time.Time, error) {
+	return s.issue(subject, role, TokenUseAccess, s.accessTTL)
+}
+
+// IssueRefreshToken は subject 向けのリフレッシュトークンと有効期限を返す。
+func (s *Signer) IssueRefreshToken(subject string) (string, time.Time, error) {
+	return s.issue(subject, "", TokenUseRefresh, s.refreshTTL)
+}
+
+// VerifyRefreshToken はリフレッシュトークンを検証し、subject を返す。
+func (s *Signer) VerifyRefreshToken(raw string) (string, error) {
+	claims, err := s.verifier.VerifyRefresh(raw)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+func (s *Signer) issue(subject, role, use string, ttl time.Duration) (string, time.Time, error) {
+	if strings.TrimSpace(subject) == "" {
+		return "", time.Time{}, errors.New("auth: subject is empty")
+	}
+	jti, err := newTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   subject,
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		TokenUse: use,
+		Role:     role,
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+
+	signed, err := jwt.NewWithClaims(s.method, claims).SignedString(s.key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// newTokenID は jti に使うランダムな識別子を生成する。
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
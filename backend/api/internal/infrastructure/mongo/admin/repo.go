@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	admin_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/admin"
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ admin_domain.Repo = (*Repo)(nil)
+
+// Repo は MongoDB バックエンドの管理者リポジトリ。
+type Repo struct {
+	collection *mongo.Collection
+}
+
+// NewRepo は Mongo コレクションから Repo を組み立てる。
+// nil の場合は panic を発生させ、DI 段階で気付けるようにする。
+func NewRepo(col *mongo.Collection) *Repo {
+	if col == nil {
+		panic("mongo admin repo: collection is nil")
+	}
+	return &Repo{collection: col}
+}
+
+// EnsureIndexes はメールアドレスの一意制約を作成する。
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("email_unique"),
+	})
+	return err
+}
+
+// Save は管理者を _id 指定で置換する（Upsert）。
+func (r *Repo) Save(ctx context.Context, entity *admin_domain.Admin) error {
+	if entity == nil {
+		return errors.New("mongo admin repo: admin is nil")
+	}
+
+	doc, err := newDocument(entity)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": doc.ID}
+	opts := options.Replace().SetUpsert(true)
+	_, err = r.collection.ReplaceOne(ctx, filter, doc, opts)
+	return err
+}
+
+// FindByID は管理者 ID で 1 件取得する。
+func (r *Repo) FindByID(ctx context.Context, id admin_vo.ID) (*admin_domain.Admin, error) {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+// FindByEmail はメールアドレスで 1 件取得する。
+func (r *Repo) FindByEmail(ctx context.Context, email admin_vo.Email) (*admin_domain.Admin, error) {
+	return r.findOne(ctx, bson.M{"email": email.Value()})
+}
+
+// FindAll は管理者をメールアドレス順にすべて取得する。
+func (r *Repo) FindAll(ctx context.Context) ([]*admin_domain.Admin, error) {
+	opts := options.Find().SetSort(bson.M{"email": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []document
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	admins := make([]*admin_domain.Admin, 0, len(docs))
+	for _, doc := range docs {
+		entity, err := doc.toEntity()
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, entity)
+	}
+	return admins, nil
+}
+
+// Count は登録済み管理者数を返す。
+func (r *Repo) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
+// CountByRole は指定ロールの管理者数を返す。
+func (r *Repo) CountByRole(ctx context.Context, role admin_vo.Role) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"role": role.Value()})
+}
+
+func (r *Repo) findOne(ctx context.Context, filter bson.M) (*admin_domain.Admin, error) {
+	var doc document
+	if err := r.collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.toEntity()
+}
+
+// admin ドキュメント構造
+type document struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Email        string             `bson:"email"`
+	PasswordHash string             `bson:"passwordHash"`
+	Role         string             `bson:"role"`
+	LastLoginAt  *time.Time         `bson:"lastLoginAt,omitempty"`
+	CreatedAt    time.Time          `bson:"createdAt"`
+	UpdatedAt    time.Time          `bson:"updatedAt"`
+}
+
+func newDocument(entity *admin_domain.Admin) (*document, error) {
+	oid, err := primitive.ObjectIDFromHex(entity.ID().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &document{
+		ID:           oid,
+		Email:        entity.Email().Value(),
+		PasswordHash: entity.PasswordHash().Value(),
+		Role:         entity.Role().Value(),
+		CreatedAt:    entity.CreatedAt().Value(),
+		UpdatedAt:    entity.UpdatedAt().Value(),
+	}
+	if last := entity.LastLoginAt(); last != nil {
+		value := last.Value()
+		doc.LastLoginAt = &value
+	}
+	return doc, nil
+}
+
+func (d *document) toEntity() (*admin_domain.Admin, error) {
+	id, err := admin_vo.NewID(d.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	email, err := admin_vo.NewEmail(d.Email)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := admin_vo.NewPasswordHash(d.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	// ロール導入前のドキュメントは最小権限の viewer として扱う。
+	roleValue := d.Role
+	if roleValue == "" {
+		roleValue = admin_vo.RoleViewer
+	}
+	role, err := admin_vo.NewRole(roleValue)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []admin_domain.Option{}
+	createdAt, err := common_vo.NewTimestamp(d.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := common_vo.NewTimestamp(d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, admin_domain.WithCreatedAt(createdAt), admin_domain.WithUpdatedAt(updatedAt))
+	if d.LastLoginAt != nil {
+		last, err := common_vo.NewTimestamp(*d.LastLoginAt)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, admin_domain.WithLastLoginAt(last))
+	}
+
+	return admin_domain.NewAdmin(id, email, hash, role, opts...)
+}
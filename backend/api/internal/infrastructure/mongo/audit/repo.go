@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	audit_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/audit"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ audit_domain.Repo = (*Repo)(nil)
+
+// Repo は MongoDB バックエンドの監査ログリポジトリ。
+// 監査ログは追記専用のため、更新・削除の操作は提供しない。
+type Repo struct {
+	collection *mongo.Collection
+}
+
+// NewRepo は Mongo コレクションから Repo を組み立てる。
+// nil の場合は panic を発生させ、DI 段階で気付けるようにする。
+func NewRepo(col *mongo.Collection) *Repo {
+	if col == nil {
+		panic("mongo audit repo: collection is nil")
+	}
+	return &Repo{collection: col}
+}
+
+// EnsureIndexes は対象別・操作者別の検索用インデックスを作成する。
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "entityType", Value: 1}, {Key: "entityId", Value: 1}, {Key: "occurredAt", Value: -1}},
+			Options: options.Index().SetName("entity_occurredAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "actor", Value: 1}, {Key: "occurredAt", Value: -1}},
+			Options: options.Index().SetName("actor_occurredAt"),
+		},
+	})
+	return err
+}
+
+// Save は監査ログを 1 件追加する。
+func (r *Repo) Save(ctx context.Context, entry *audit_domain.Entry) error {
+	if entry == nil {
+		return errors.New("mongo audit repo: entry is nil")
+	}
+	_, err := r.collection.InsertOne(ctx, newDocument(entry))
+	return err
+}
+
+// Find は条件に合う監査ログを新しい順に返す。件数とセットで返す。
+func (r *Repo) Find(ctx context.Context, filter audit_domain.Filter, page common_vo.Pagination) ([]*audit_domain.Entry, int64, error) {
+	query := bson.M{}
+	if filter.EntityType != "" {
+		query["entityType"] = string(filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query["entityId"] = filter.EntityID
+	}
+	if filter.Actor != "" {
+		query["actor"] = filter.Actor
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "occurredAt", Value: -1}, {Key: "_id", Value: -1}})
+	if !page.IsZero() {
+		opts.SetSkip(int64(page.Offset()))
+		opts.SetLimit(int64(page.Limit()))
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []document
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*audit_domain.Entry, 0, len(docs))
+	for _, doc := range docs {
+		entry, err := doc.toEntity()
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, nil
+}
+
+// audit ドキュメント構造
+type document struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Actor      string             `bson:"actor"`
+	RequestID  string             `bson:"requestId,omitempty"`
+	EntityType string             `bson:"entityType"`
+	EntityID   string             `bson:"entityId"`
+	Action     string             `bson:"action"`
+	Changes    []changeDocument   `bson:"changes"`
+	OccurredAt time.Time          `bson:"occurredAt"`
+}
+
+type changeDocument struct {
+	Field  string      `bson:"field"`
+	Before interface{} `bson:"before"`
+	After  interface{} `bson:"after"`
+}
+
+func newDocument(entry *audit_domain.Entry) *document {
+	id := primitive.NewObjectID()
+	if oid, err := primitive.ObjectIDFromHex(entry.ID()); err == nil {
+		id = oid
+	}
+
+	changes := make([]changeDocument, 0, len(entry.Changes()))
+	for _, c := range entry.Changes() {
+		changes = append(changes, changeDocument{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	return &document{
+		ID:         id,
+		Actor:      entry.Actor(),
+		RequestID:  entry.RequestID(),
+		EntityType: string(entry.EntityType()),
+		EntityID:   entry.EntityID(),
+		Action:     string(entry.Action()),
+		Changes:    changes,
+		OccurredAt: entry.OccurredAt().Value(),
+	}
+}
+
+func (d *document) toEntity() (*audit_domain.Entry, error) {
+	occurredAt, err := common_vo.NewTimestamp(d.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]audit_domain.Change, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		changes = append(changes, audit_domain.Change{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	return audit_domain.NewEntry(
+		d.Actor,
+		audit_domain.EntityType(d.EntityType),
+		d.EntityID,
+		audit_domain.Action(d.Action),
+		changes,
+		audit_domain.WithID(d.ID.Hex()),
+		audit_domain.WithRequestID(d.RequestID),
+		audit_domain.WithOccurredAt(occurredAt),
+	)
+}
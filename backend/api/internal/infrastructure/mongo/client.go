@@ -1 +1,67 @@
+// Package mongo は各リポジトリで共有する MongoDB 関連の処理をまとめる。
 package mongo
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// illegalOperationCode はスタンドアロン構成でトランザクションを開始した場合に返されるエラーコード。
+const illegalOperationCode = 20
+
+// Transactor は複数コレクションへの書き込みを 1 つのトランザクションで実行する。
+// トランザクションはレプリカセット/シャードクラスタでのみ利用できるため、
+// スタンドアロン構成（ローカル開発など）を検出した場合はトランザクションなしで実行する。
+type Transactor struct {
+	client *mongo.Client
+	// standalone はトランザクション非対応と判明したかどうか。以降の呼び出しでは再試行しない。
+	standalone atomic.Bool
+}
+
+// NewTransactor は Mongo クライアントから Transactor を生成する。
+// nil の場合は panic を発生させ、DI 段階で気付けるようにする。
+func NewTransactor(client *mongo.Client) *Transactor {
+	if client == nil {
+		panic("mongo transactor: client is nil")
+	}
+	return &Transactor{client: client}
+}
+
+// WithinTransaction は fn をトランザクション内で実行する。fn がエラーを返した場合はロールバックする。
+// fn にはセッションに紐づいたコンテキストが渡されるため、リポジトリ呼び出しには必ずそれを使うこと。
+// 一時的なエラーの場合はドライバが fn を再実行するため、fn は再実行しても問題ない処理にする。
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if t.standalone.Load() {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	if err != nil && isTransactionUnsupported(err) {
+		// トランザクション開始時点で失敗しているため、書き込みは行われていない。
+		if !t.standalone.Swap(true) {
+			log.Printf("mongo transactor: transactions are not supported by this deployment; running without transactions")
+		}
+		return fn(ctx)
+	}
+	return err
+}
+
+func isTransactionUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == illegalOperationCode
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package mongo
+
+import (
+	"strconv"
+	"time"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FieldKind はカーソルに埋め込む並び替え項目の型を表す。
+type FieldKind int
+
+const (
+	FieldTime FieldKind = iota
+	FieldNumber
+	FieldObjectID
+)
+
+// SortField は一覧の並び替えに使う項目を表す。
+// 同じ値のドキュメントでも順序が決まるよう、最後の項目には _id を指定する。
+type SortField struct {
+	Name string
+	Desc bool
+	Kind FieldKind
+}
+
+// SortDocument は fields の順で並べる $sort の指定を返す。
+// reverse を指定した場合は逆順にし、カーソルより手前のページを境に近い順から取得する。
+func SortDocument(fields []SortField, reverse bool) bson.D {
+	sort := make(bson.D, 0, len(fields))
+	for _, f := range fields {
+		dir := 1
+		if f.Desc != reverse {
+			dir = -1
+		}
+		sort = append(sort, bson.E{Key: f.Name, Value: dir})
+	}
+	return sort
+}
+
+// KeysetCondition は cursor の位置より後（Backward の場合は手前）のドキュメントに限定する条件を返す。
+// (a, b, _id) の順で並べる場合、a が境を越えるか、a が等しく b が境を越えるか、… のいずれかを満たすものとなる。
+// カーソルの値の数や形式が fields と合わない場合は common_vo.ErrInvalidCursor を返す。
+func KeysetCondition(fields []SortField, cursor common_vo.Cursor) (bson.M, error) {
+	raw := cursor.Values()
+	if len(raw) != len(fields) {
+		return nil, common_vo.ErrInvalidCursor
+	}
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		v, err := decodeCursorValue(f.Kind, raw[i])
+		if err != nil {
+			return nil, common_vo.ErrInvalidCursor
+		}
+		values[i] = v
+	}
+
+	branches := make(bson.A, 0, len(fields))
+	for i, f := range fields {
+		branch := bson.M{}
+		for j := 0; j < i; j++ {
+			branch[fields[j].Name] = values[j]
+		}
+		op := "$gt"
+		if f.Desc != cursor.Backward() {
+			op = "$lt"
+		}
+		branch[f.Name] = bson.M{op: values[i]}
+		branches = append(branches, branch)
+	}
+	return bson.M{"$or": branches}, nil
+}
+
+// TrimPage は 1 件多く取得した docs を 1 ページ分に切り詰め、前後のページがあるかを返す。
+// Backward のカーソルで逆順に取得した場合は元の並び順に戻す。
+// カーソルの位置から取得した場合、境となったドキュメントが前（Backward の場合は後）のページにあるものとみなす。
+func TrimPage[T any](docs []T, page common_vo.Pagination) (items []T, hasNext, hasPrev bool) {
+	if page.IsZero() {
+		return docs, false, false
+	}
+	more := len(docs) > page.Limit()
+	if more {
+		docs = docs[:page.Limit()]
+	}
+	if !page.UsesCursor() {
+		return docs, more, page.Offset() > 0
+	}
+	if !page.Cursor().Backward() {
+		return docs, more, true
+	}
+	for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+		docs[i], docs[j] = docs[j], docs[i]
+	}
+	return docs, true, more
+}
+
+// PageCursors は 1 ページ分の items の先頭・末尾から、前後のページを取得するカーソルを返す。
+// value は items の要素から並び替え項目の値を取り出す。該当するページがない場合はゼロ値を返す。
+func PageCursors[T any](items []T, sortKey common_vo.SortKey, fields []SortField, hasNext, hasPrev bool, value func(T, string) interface{}) (next, prev common_vo.Cursor) {
+	if len(items) == 0 {
+		return next, prev
+	}
+	values := func(item T) []string {
+		encoded := make([]string, 0, len(fields))
+		for _, f := range fields {
+			encoded = append(encoded, encodeCursorValue(value(item, f.Name)))
+		}
+		return encoded
+	}
+	if hasNext {
+		next = common_vo.NewCursor(sortKey, false, values(items[len(items)-1])...)
+	}
+	if hasPrev {
+		prev = common_vo.NewCursor(sortKey, true, values(items[0])...)
+	}
+	return next, prev
+}
+
+func encodeCursorValue(v interface{}) string {
+	switch x := v.(type) {
+	case time.Time:
+		return x.UTC().Format(time.RFC3339Nano)
+	case primitive.ObjectID:
+		return x.Hex()
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func decodeCursorValue(kind FieldKind, raw string) (interface{}, error) {
+	switch kind {
+	case FieldTime:
+		return time.Parse(time.RFC3339Nano, raw)
+	case FieldObjectID:
+		return primitive.ObjectIDFromHex(raw)
+	default:
+		return strconv.ParseFloat(raw, 64)
+	}
+}
@@ -0,0 +1,342 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	dashboard_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/dashboard"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	submission_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/submission"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ dashboard_domain.Repo = (*Repo)(nil)
+
+// Repo は店舗・アンケート・投稿の各コレクションを集計する MongoDB バックエンドのダッシュボードリポジトリ。
+// 書き込みは行わず、各集約のリポジトリが保存したドキュメントをそのまま集計する。
+type Repo struct {
+	storeCollection      *mongo.Collection
+	surveyCollection     *mongo.Collection
+	submissionCollection *mongo.Collection
+}
+
+// NewRepo は各コレクションから Repo を組み立てる。
+// nil の場合は panic を発生させ、DI 段階で気付けるようにする。
+func NewRepo(stores, surveys, submissions *mongo.Collection) *Repo {
+	if stores == nil {
+		panic("mongo dashboard repo: store collection is nil")
+	}
+	if surveys == nil {
+		panic("mongo dashboard repo: survey collection is nil")
+	}
+	if submissions == nil {
+		panic("mongo dashboard repo: submission collection is nil")
+	}
+	return &Repo{storeCollection: stores, surveyCollection: surveys, submissionCollection: submissions}
+}
+
+// publishedAtExpr はアンケートの公開日を表す式。承認日時を持たない既存ドキュメントは作成日時とする。
+var publishedAtExpr = bson.D{{Key: "$ifNull", Value: bson.A{"$reviewedAt", "$createdAt"}}}
+
+// publishedSurveyFilter は論理削除されていない承認済みアンケートの条件。status を持たない既存ドキュメントは承認済み扱い。
+func publishedSurveyFilter() bson.D {
+	return bson.D{
+		{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "status", Value: bson.D{{Key: "$nin", Value: bson.A{survey_vo.StatusPending, survey_vo.StatusRejected}}}},
+	}
+}
+
+// SubmissionCounts は期間内に受け付けた一般投稿の件数を推移で返す。
+func (r *Repo) SubmissionCounts(ctx context.Context, window dashboard_domain.Window) ([]dashboard_domain.CountPoint, error) {
+	match := bson.D{{Key: "receivedAt", Value: bson.D{{Key: "$gte", Value: window.Since}}}}
+	return countByPeriod(ctx, r.submissionCollection, match, "$receivedAt", window)
+}
+
+// PublishedSurveyCounts は期間内に公開されたアンケートの件数を推移で返す。
+func (r *Repo) PublishedSurveyCounts(ctx context.Context, window dashboard_domain.Window) ([]dashboard_domain.CountPoint, error) {
+	match := append(publishedSurveyFilter(), bson.E{
+		Key: "$expr", Value: bson.D{{Key: "$gte", Value: bson.A{publishedAtExpr, window.Since}}},
+	})
+	return countByPeriod(ctx, r.surveyCollection, match, publishedAtExpr, window)
+}
+
+// NewStoreCounts は期間内に登録された店舗の件数を推移で返す。
+func (r *Repo) NewStoreCounts(ctx context.Context, window dashboard_domain.Window) ([]dashboard_domain.CountPoint, error) {
+	match := bson.D{
+		{Key: "createdAt", Value: bson.D{{Key: "$gte", Value: window.Since}}},
+		{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	return countByPeriod(ctx, r.storeCollection, match, "$createdAt", window)
+}
+
+// countByPeriod は match に一致するドキュメントを dateExpr の日時で期間ごとに数える。件数が 0 の期間は含まれない。
+func countByPeriod(
+	ctx context.Context,
+	col *mongo.Collection,
+	match bson.D,
+	dateExpr interface{},
+	window dashboard_domain.Window,
+) ([]dashboard_domain.CountPoint, error) {
+	trunc := bson.D{
+		{Key: "date", Value: dateExpr},
+		{Key: "unit", Value: string(window.Granularity)},
+		{Key: "timezone", Value: window.Timezone},
+	}
+	if window.Granularity == dashboard_domain.GranularityWeek {
+		trunc = append(trunc, bson.E{Key: "startOfWeek", Value: "monday"})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$dateTrunc", Value: trunc}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		Start time.Time `bson:"_id"`
+		Count int64     `bson:"count"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	points := make([]dashboard_domain.CountPoint, 0, len(docs))
+	for _, doc := range docs {
+		points = append(points, dashboard_domain.CountPoint{Start: doc.Start, Count: doc.Count})
+	}
+	return points, nil
+}
+
+// PendingCounts は確認待ちの一般投稿と承認待ちのアンケートの件数を返す。
+func (r *Repo) PendingCounts(ctx context.Context) (dashboard_domain.PendingCounts, error) {
+	submissions, err := r.submissionCollection.CountDocuments(ctx, bson.M{"status": submission_vo.StatusPending})
+	if err != nil {
+		return dashboard_domain.PendingCounts{}, err
+	}
+	surveys, err := r.surveyCollection.CountDocuments(ctx, bson.M{
+		"status":    survey_vo.StatusPending,
+		"deletedAt": bson.M{"$exists": false},
+	})
+	if err != nil {
+		return dashboard_domain.PendingCounts{}, err
+	}
+	return dashboard_domain.PendingCounts{Submissions: submissions, Surveys: surveys}, nil
+}
+
+// StoresWithoutSurveys は承認済みアンケートが 1 件もない店舗を登録の古い順に返す。
+func (r *Repo) StoresWithoutSurveys(ctx context.Context, limit int) ([]dashboard_domain.StoreActivity, int64, error) {
+	return r.storeActivities(ctx,
+		bson.D{{Key: "surveyCount", Value: 0}},
+		bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+		limit,
+	)
+}
+
+// StaleStores は最新の承認済みアンケートが before より前の店舗を、最新のアンケートが古い順に返す。
+// アンケートが 1 件もない店舗は StoresWithoutSurveys で扱うため含めない。
+func (r *Repo) StaleStores(ctx context.Context, before time.Time, limit int) ([]dashboard_domain.StoreActivity, int64, error) {
+	return r.storeActivities(ctx,
+		bson.D{{Key: "lastSurveyedAt", Value: bson.D{{Key: "$lt", Value: before}}}},
+		bson.D{{Key: "lastSurveyedAt", Value: 1}, {Key: "_id", Value: 1}},
+		limit,
+	)
+}
+
+// storeActivities は論理削除されていない店舗に承認済みアンケートの件数と最新の作成日時を付け、
+// match に一致する店舗の総数と sort 順の先頭 limit 件を返す。
+func (r *Repo) storeActivities(ctx context.Context, match, sort bson.D, limit int) ([]dashboard_domain.StoreActivity, int64, error) {
+	surveyMatch := append(publishedSurveyFilter(), bson.E{
+		Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$storeId", "$$storeId"}}},
+	})
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: r.surveyCollection.Name()},
+			{Key: "let", Value: bson.D{{Key: "storeId", Value: "$_id"}}},
+			{Key: "pipeline", Value: mongo.Pipeline{
+				{{Key: "$match", Value: surveyMatch}},
+				{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: nil},
+					{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+					{Key: "last", Value: bson.D{{Key: "$max", Value: "$createdAt"}}},
+				}}},
+			}},
+			{Key: "as", Value: "activity"},
+		}}},
+		{{Key: "$addFields", Value: bson.D{
+			{Key: "surveyCount", Value: bson.D{{Key: "$ifNull", Value: bson.A{bson.D{{Key: "$first", Value: "$activity.count"}}, 0}}}},
+			{Key: "lastSurveyedAt", Value: bson.D{{Key: "$first", Value: "$activity.last"}}},
+		}}},
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+			{Key: "items", Value: bson.A{
+				bson.D{{Key: "$sort", Value: sort}},
+				bson.D{{Key: "$limit", Value: int64(limit)}},
+			}},
+		}}},
+	}
+
+	cursor, err := r.storeCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Items []storeActivityDocument `bson:"items"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+	if len(result) == 0 {
+		return []dashboard_domain.StoreActivity{}, 0, nil
+	}
+
+	var total int64
+	if len(result[0].Total) > 0 {
+		total = result[0].Total[0].Count
+	}
+	items := make([]dashboard_domain.StoreActivity, 0, len(result[0].Items))
+	for _, doc := range result[0].Items {
+		item, err := doc.toActivity()
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	return items, total, nil
+}
+
+// TopPrefectures は since 以降に公開されたアンケート・受け付けた投稿・登録された店舗の件数を都道府県ごとに合計し、
+// 合計の多い順に limit 件返す。
+func (r *Repo) TopPrefectures(ctx context.Context, since time.Time, limit int) ([]dashboard_domain.PrefectureActivity, error) {
+	surveyMatch := append(publishedSurveyFilter(), bson.E{
+		Key: "$expr", Value: bson.D{{Key: "$gte", Value: bson.A{publishedAtExpr, since}}},
+	})
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: surveyMatch}},
+		{{Key: "$project", Value: bson.D{{Key: "prefecture", Value: "$storePrefecture"}, {Key: "kind", Value: "survey"}}}},
+		{{Key: "$unionWith", Value: bson.D{
+			{Key: "coll", Value: r.submissionCollection.Name()},
+			{Key: "pipeline", Value: mongo.Pipeline{
+				{{Key: "$match", Value: bson.D{
+					{Key: "receivedAt", Value: bson.D{{Key: "$gte", Value: since}}},
+					{Key: "payload.prefecture", Value: bson.D{{Key: "$nin", Value: bson.A{nil, ""}}}},
+				}}},
+				{{Key: "$project", Value: bson.D{{Key: "prefecture", Value: "$payload.prefecture"}, {Key: "kind", Value: "submission"}}}},
+			}},
+		}}},
+		{{Key: "$unionWith", Value: bson.D{
+			{Key: "coll", Value: r.storeCollection.Name()},
+			{Key: "pipeline", Value: mongo.Pipeline{
+				{{Key: "$match", Value: bson.D{
+					{Key: "createdAt", Value: bson.D{{Key: "$gte", Value: since}}},
+					{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+				}}},
+				{{Key: "$project", Value: bson.D{{Key: "prefecture", Value: "$prefecture"}, {Key: "kind", Value: "store"}}}},
+			}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$prefecture"},
+			{Key: "surveys", Value: countKind("survey")},
+			{Key: "submissions", Value: countKind("submission")},
+			{Key: "newStores", Value: countKind("store")},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "total", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+
+	cursor, err := r.surveyCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		Prefecture  string `bson:"_id"`
+		Surveys     int64  `bson:"surveys"`
+		Submissions int64  `bson:"submissions"`
+		NewStores   int64  `bson:"newStores"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	result := make([]dashboard_domain.PrefectureActivity, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, dashboard_domain.PrefectureActivity{
+			Prefecture:  doc.Prefecture,
+			Surveys:     doc.Surveys,
+			Submissions: doc.Submissions,
+			NewStores:   doc.NewStores,
+		})
+	}
+	return result, nil
+}
+
+func countKind(kind string) bson.D {
+	return bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{
+		bson.D{{Key: "$eq", Value: bson.A{"$kind", kind}}}, 1, 0,
+	}}}}}
+}
+
+// storeActivityDocument は店舗ドキュメントに集計結果を付けたもの。
+type storeActivityDocument struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	Name           string             `bson:"name"`
+	BranchName     *string            `bson:"branchName,omitempty"`
+	Prefecture     string             `bson:"prefecture"`
+	CreatedAt      time.Time          `bson:"createdAt"`
+	SurveyCount    int                `bson:"surveyCount"`
+	LastSurveyedAt *time.Time         `bson:"lastSurveyedAt,omitempty"`
+}
+
+func (d storeActivityDocument) toActivity() (dashboard_domain.StoreActivity, error) {
+	id, err := store_vo.NewID(d.ID.Hex())
+	if err != nil {
+		return dashboard_domain.StoreActivity{}, err
+	}
+	name, err := store_vo.NewName(d.Name)
+	if err != nil {
+		return dashboard_domain.StoreActivity{}, err
+	}
+	pref, err := store_vo.NewPrefecture(d.Prefecture)
+	if err != nil {
+		return dashboard_domain.StoreActivity{}, err
+	}
+	activity := dashboard_domain.StoreActivity{
+		StoreID:        id,
+		Name:           name,
+		Prefecture:     pref,
+		CreatedAt:      d.CreatedAt,
+		SurveyCount:    d.SurveyCount,
+		LastSurveyedAt: d.LastSurveyedAt,
+	}
+	if d.BranchName != nil {
+		branch, err := store_vo.NewBranchName(*d.BranchName)
+		if err != nil {
+			return dashboard_domain.StoreActivity{}, err
+		}
+		activity.BranchName = &branch
+	}
+	return activity, nil
+}
@@ -0,0 +1,23 @@
+package mongo
+
+import (
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// RangeCondition は数値の範囲をフィールドの条件 ($gte/$lte) に変換する。
+// 範囲の指定がない場合は nil を返すため、呼び出し側で条件に含めるかを判定する。
+// 値が null や存在しないドキュメントは、いずれの端を指定した場合も一致しない。
+func RangeCondition(r common_vo.Range) bson.M {
+	if r.IsZero() {
+		return nil
+	}
+	cond := bson.M{}
+	if min := r.Min(); min != nil {
+		cond["$gte"] = *min
+	}
+	if max := r.Max(); max != nil {
+		cond["$lte"] = *max
+	}
+	return cond
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"regexp"
+	"strings"
 	"time"
 
 	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	"github.com/sngm3741/makoto-club-services/api/internal/domain/textnorm"
 	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	mongo_infra "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -37,6 +41,55 @@ func NewRepo(storeCol *mongo.Collection, surveyCol *mongo.Collection) *Repo {
 	return &Repo{collection: storeCol, surveyCollection: surveyCol}
 }
 
+// EnsureIndexes は店舗名の照合と、照合の候補を都道府県・業種で絞り込むためのインデックス、
+// 検索の並び順 (更新・参考になった・平均稼ぎの順) のインデックスを作成する。
+// あわせて並び替えに使う統計の項目を持たない既存ドキュメントに値を補う。
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	if err := r.backfillSortStats(ctx); err != nil {
+		return err
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "searchKey", Value: 1}},
+			Options: options.Index().SetName("searchKey"),
+		},
+		{
+			Keys:    bson.D{{Key: "prefecture", Value: 1}, {Key: "industry", Value: 1}},
+			Options: options.Index().SetName("prefecture_industry"),
+		},
+		{
+			Keys:    bson.D{{Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("updatedAt_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "stats.helpfulCount", Value: -1}, {Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("stats.helpfulCount_updatedAt_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "stats.earningSortKey", Value: -1}, {Key: "updatedAt", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("stats.earningSortKey_updatedAt_id"),
+		},
+	})
+	return err
+}
+
+// backfillSortStats は並び替えに使う統計の項目を持たない既存ドキュメントに値を補う。
+// カーソルの境の条件は欠けたフィールドに一致しないため、欠けたままだと 2 ページ目以降から漏れる。
+// 参考になった数の合計はアンケートを集計しないと求まらないため 0 とし、recalculate-store-stats で正しい値に更新する。
+func (r *Repo) backfillSortStats(ctx context.Context) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"$or": bson.A{
+			bson.M{"stats.helpfulCount": bson.M{"$exists": false}},
+			bson.M{"stats.earningSortKey": bson.M{"$exists": false}},
+		}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.D{
+			{Key: "stats.helpfulCount", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$stats.helpfulCount", 0}}}},
+			{Key: "stats.earningSortKey", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$stats.avgEarning", 0}}}},
+		}}}},
+	)
+	return err
+}
+
 // Save は Store 集約を _id 指定で置換する（Upsert）。
 // 時刻や optional フィールドは newDocument 内で適切に marshaling される。
 func (r *Repo) Save(ctx context.Context, entity *store_domain.Store) error {
@@ -62,9 +115,24 @@ func (r *Repo) FindByID(ctx context.Context, id store_vo.ID) (*store_domain.Stor
 	if err != nil {
 		return nil, err
 	}
-	filter := bson.M{"_id": oid, "deletedAt": bson.M{"$exists": false}}
+	return r.findOne(ctx, bson.M{"_id": oid, "deletedAt": bson.M{"$exists": false}})
+}
+
+// FindByIDIncludingDeleted はソフトデリート済みも含めて単一店舗を検索する。復元・完全削除で使う。
+func (r *Repo) FindByIDIncludingDeleted(ctx context.Context, id store_vo.ID) (*store_domain.Store, error) {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+func (r *Repo) findOne(ctx context.Context, filter bson.M) (*store_domain.Store, error) {
 	var doc document
 	if err := r.collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return doc.toEntity()
@@ -83,100 +151,166 @@ func (r *Repo) FindByArea(ctx context.Context, area store_vo.Area, page common_v
 	return r.findMany(ctx, filter, page)
 }
 
-// Search は任意条件で店舗を取得する。件数とセットで返す。
-func (r *Repo) Search(ctx context.Context, filter store_domain.SearchFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*store_domain.Store, int64, error) {
+// Search は任意条件で店舗を取得する。ページ番号で指定した場合は件数とセットで返し、
+// カーソルで指定した場合は件数を数えずにカーソルの位置から取得する。
+func (r *Repo) Search(ctx context.Context, filter store_domain.SearchFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*store_domain.Store, common_vo.PageInfo, error) {
 	mongoFilter := bson.M{"deletedAt": bson.M{"$exists": false}}
-	if filter.Prefecture != nil {
-		mongoFilter["prefecture"] = filter.Prefecture.Value()
-	}
-	if filter.Area != nil {
-		mongoFilter["area"] = filter.Area.Value()
-	}
-	if filter.Industry != nil {
-		mongoFilter["industry"] = filter.Industry.Value()
-	}
-	if filter.Genre != nil {
-		mongoFilter["genre"] = filter.Genre.Value()
+	if len(filter.Prefectures) > 0 {
+		mongoFilter["prefecture"] = bson.M{"$in": inValues(filter.Prefectures)}
+	}
+	if len(filter.Areas) > 0 {
+		mongoFilter["area"] = bson.M{"$in": inValues(filter.Areas)}
+	}
+	if len(filter.Industries) > 0 {
+		mongoFilter["industry"] = bson.M{"$in": inValues(filter.Industries)}
+	}
+	if len(filter.Genres) > 0 {
+		mongoFilter["genre"] = bson.M{"$in": inValues(filter.Genres)}
+	}
+	// 統計の範囲は UpdateStats で保存済みの集計値で絞り込む。
+	for field, r := range map[string]common_vo.Range{
+		"stats.avgRating":   filter.Rating,
+		"stats.surveyCount": filter.SurveyCount,
+		"stats.avgEarning":  filter.AvgEarning,
+		"stats.avgWaitTime": filter.AvgWaitTime,
+	} {
+		if cond := mongo_infra.RangeCondition(r); cond != nil {
+			mongoFilter[field] = cond
+		}
 	}
-	if filter.NameKeyword != "" {
-		pattern := regexp.QuoteMeta(filter.NameKeyword)
-		regex := primitive.Regex{Pattern: pattern, Options: "i"}
-		mongoFilter["$or"] = []bson.M{
-			{"name": regex},
-			{"branchName": regex},
+	// toEntity は open/close のどちらかが空の営業時間を未登録として扱うため、両方が空でない場合のみ登録ありとする。
+	if filter.HasBusinessHours != nil {
+		registered := bson.M{
+			"businessHours.open":  bson.M{"$type": "string", "$ne": ""},
+			"businessHours.close": bson.M{"$type": "string", "$ne": ""},
+		}
+		if *filter.HasBusinessHours {
+			for field, cond := range registered {
+				mongoFilter[field] = cond
+			}
+		} else {
+			mongoFilter["$nor"] = bson.A{registered}
 		}
 	}
-
-	total, err := r.collection.CountDocuments(ctx, mongoFilter)
-	if err != nil {
-		return nil, 0, err
+	// 正規化すると検索できる文字が残らない検索語（記号のみなど）は条件に含めない。
+	if key := textnorm.Normalize(filter.NameKeyword); key != "" {
+		mongoFilter["searchKey"] = primitive.Regex{Pattern: regexp.QuoteMeta(key)}
 	}
 
-	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: mongoFilter}},
-		{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: r.surveyCollection.Name()},
-				{Key: "let", Value: bson.D{{Key: "storeId", Value: "$_id"}}},
-				{Key: "pipeline", Value: mongo.Pipeline{
-					{{Key: "$match", Value: bson.D{
-						{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$storeId", "$$storeId"}}}},
-						{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}},
-					}}},
-				}},
-				{Key: "as", Value: "surveys"},
-			}},
-		},
-		{
-			{Key: "$addFields", Value: bson.D{
-				{Key: "surveyCount", Value: bson.D{{Key: "$size", Value: "$surveys"}}},
-				{Key: "helpfulCount", Value: bson.D{{Key: "$sum", Value: "$surveys.helpfulCount"}}},
-				{Key: "averageEarningAgg", Value: bson.D{
-					{Key: "$cond", Value: bson.A{
-						bson.D{{Key: "$gt", Value: bson.A{bson.D{{Key: "$size", Value: "$surveys"}}, 0}}},
-						bson.D{{Key: "$avg", Value: "$surveys.averageEarning"}},
-						0,
-					}},
-				}},
-			}},
-		},
-		{{Key: "$project", Value: bson.D{{Key: "surveys", Value: 0}}}},
+	fields := sortFields(sort)
+	var info common_vo.PageInfo
+	var keyset bson.M
+	if page.UsesCursor() {
+		if !page.Cursor().SortKey().Equals(sort) {
+			return nil, info, common_vo.ErrInvalidCursor
+		}
+		var err error
+		if keyset, err = mongo_infra.KeysetCondition(fields, page.Cursor()); err != nil {
+			return nil, info, err
+		}
+	} else {
+		total, err := r.collection.CountDocuments(ctx, mongoFilter)
+		if err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
 	}
 
-	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: buildSort(sort)}})
-
+	// 並び替えの項目も UpdateStats で保存済みの集計値のため、アンケートを結合せずに店舗のドキュメントだけで並べる。
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: mongoFilter}}}
+	if keyset != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: keyset}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: mongo_infra.SortDocument(fields, page.Cursor().Backward())}})
 	if !page.IsZero() {
+		// 次のページがあるかを判定するため 1 件多く取得する。
 		pipeline = append(pipeline,
 			bson.D{{Key: "$skip", Value: int64(page.Offset())}},
-			bson.D{{Key: "$limit", Value: int64(page.Limit())}},
+			bson.D{{Key: "$limit", Value: int64(page.Limit() + 1)}},
 		)
 	}
 
 	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, 0, err
+		return nil, info, err
 	}
 	defer cursor.Close(ctx)
 
 	var docs []document
 	if err := cursor.All(ctx, &docs); err != nil {
-		return nil, 0, err
+		return nil, info, err
 	}
+	docs, hasNext, hasPrev := mongo_infra.TrimPage(docs, page)
+	info.Next, info.Prev = mongo_infra.PageCursors(docs, sort, fields, hasNext, hasPrev, searchSortValue)
 
 	stores := make([]*store_domain.Store, 0, len(docs))
 	for _, doc := range docs {
 		entity, err := doc.toEntity()
 		if err != nil {
-			return nil, 0, err
+			return nil, info, err
 		}
 		stores = append(stores, entity)
 	}
 
+	return stores, info, nil
+}
+
+// inValues は VO の値を $in で照合する配列に変換する。
+func inValues[T interface{ Value() string }](items []T) bson.A {
+	values := make(bson.A, 0, len(items))
+	for _, item := range items {
+		values = append(values, item.Value())
+	}
+	return values
+}
+
+// ReindexSearchKeys は論理削除済みを含む全店舗の店舗名の検索キーを作り直し、更新した件数を返す。
+// 検索キーを持たない既存ドキュメントの移行や、正規化の方法を変えた場合に使う。
+func (r *Repo) ReindexSearchKeys(ctx context.Context) (int64, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	for cursor.Next(ctx) {
+		var doc document
+		if err := cursor.Decode(&doc); err != nil {
+			return updated, err
+		}
+		entity, err := doc.toEntity()
+		if err != nil {
+			return updated, err
+		}
+		key := store_vo.SearchKey(entity.Name(), entity.BranchName())
+		if key == doc.SearchKey {
+			continue
+		}
+		if _, err := r.collection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"searchKey": key}}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, cursor.Err()
+}
+
+// FindDeleted は論理削除された店舗を削除日時の新しい順に取得する。件数とセットで返す。
+func (r *Repo) FindDeleted(ctx context.Context, page common_vo.Pagination) ([]*store_domain.Store, int64, error) {
+	filter := bson.M{"deletedAt": bson.M{"$exists": true}}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	stores, err := r.findSorted(ctx, filter, bson.D{{Key: "deletedAt", Value: -1}}, page)
+	if err != nil {
+		return nil, 0, err
+	}
 	return stores, total, nil
 }
 
-// Delete は物理削除を行う。論理削除が欲しい場合は Usecase 側で DeletedAt を設定する。
-func (r *Repo) Delete(ctx context.Context, id store_vo.ID) error {
+// Purge は店舗ドキュメントを物理削除する。通常の削除は MarkDeleted + Save による論理削除を使う。
+func (r *Repo) Purge(ctx context.Context, id store_vo.ID) error {
 	oid, err := primitive.ObjectIDFromHex(id.Value())
 	if err != nil {
 		return err
@@ -185,10 +319,180 @@ func (r *Repo) Delete(ctx context.Context, id store_vo.ID) error {
 	return err
 }
 
+// UpdateStats は統計と平均総評のみを更新する。店舗情報の編集と競合しないよう、ドキュメント全体は置き換えない。
+func (r *Repo) UpdateStats(ctx context.Context, id store_vo.ID, stats store_vo.Stats) error {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return err
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{
+		"stats":         newStatsDocument(stats),
+		"averageRating": stats.AverageRating().Value(),
+	}})
+	return err
+}
+
+// FindAll はソフトデリートされていない店舗をすべて取得する。店舗の照合など全件を走査する用途向け。
+func (r *Repo) FindAll(ctx context.Context) ([]*store_domain.Store, error) {
+	return r.findMany(ctx, bson.M{"deletedAt": bson.M{"$exists": false}}, common_vo.Pagination{})
+}
+
+// FindMatchCandidates は照合の候補となる店舗を最大 limit 件返す。
+// 店舗名の n-gram のいずれかを含む店舗を先に取得し、残りの枠を都道府県・業種が一致する店舗で埋める。
+// 都道府県・業種の一致が多くても店舗名が一致する店舗が枠から漏れないよう、2 つの条件は別々に問い合わせる。
+// どちらも _id 順に取得するため、同じ条件なら同じ候補を返す。
+func (r *Repo) FindMatchCandidates(ctx context.Context, filter store_domain.CandidateFilter, limit int) ([]*store_domain.Store, error) {
+	stores := []*store_domain.Store{}
+
+	if len(filter.NameGrams) > 0 {
+		patterns := make([]string, 0, len(filter.NameGrams))
+		for _, gram := range filter.NameGrams {
+			patterns = append(patterns, regexp.QuoteMeta(gram))
+		}
+		query := bson.M{"deletedAt": bson.M{"$exists": false}, "searchKey": primitive.Regex{Pattern: strings.Join(patterns, "|")}}
+		matched, err := r.findCandidates(ctx, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, matched...)
+	}
+	if limit > 0 && len(stores) >= limit {
+		return stores, nil
+	}
+
+	attrs := bson.M{"deletedAt": bson.M{"$exists": false}}
+	if filter.Prefecture != nil {
+		attrs["prefecture"] = filter.Prefecture.Value()
+	}
+	if filter.Industry != nil {
+		attrs["industry"] = filter.Industry.Value()
+	}
+	if len(attrs) == 1 {
+		return stores, nil
+	}
+	if len(stores) > 0 {
+		seen := make([]primitive.ObjectID, 0, len(stores))
+		for _, store := range stores {
+			oid, err := primitive.ObjectIDFromHex(store.ID().Value())
+			if err != nil {
+				return nil, err
+			}
+			seen = append(seen, oid)
+		}
+		attrs["_id"] = bson.M{"$nin": seen}
+	}
+	remaining := 0
+	if limit > 0 {
+		remaining = limit - len(stores)
+	}
+	matched, err := r.findCandidates(ctx, attrs, remaining)
+	if err != nil {
+		return nil, err
+	}
+	return append(stores, matched...), nil
+}
+
+// findCandidates は filter に合う店舗を _id 順に最大 limit 件返す。limit が 0 以下の場合は件数を制限しない。
+func (r *Repo) findCandidates(ctx context.Context, filter bson.M, limit int) ([]*store_domain.Store, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []document
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	stores := make([]*store_domain.Store, 0, len(docs))
+	for _, doc := range docs {
+		entity, err := doc.toEntity()
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, entity)
+	}
+	return stores, nil
+}
+
+// FindForComparison は指定した店舗に承認済みアンケートの集計結果を付けて 1 回の集計で取得する。
+// 保存済みの stats ではなくその場で集計するため、統計の更新漏れがあっても比較結果はずれない。
+func (r *Repo) FindForComparison(ctx context.Context, ids []store_vo.ID) ([]store_domain.Comparison, error) {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id.Value())
+		if err != nil {
+			return nil, err
+		}
+		oids = append(oids, oid)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$in", Value: oids}}},
+			{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+		}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: r.surveyCollection.Name()},
+			{Key: "let", Value: bson.D{{Key: "storeId", Value: "$_id"}}},
+			{Key: "pipeline", Value: mongo.Pipeline{
+				{{Key: "$match", Value: bson.D{
+					{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$storeId", "$$storeId"}}}},
+					{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+					{Key: "status", Value: bson.D{{Key: "$nin", Value: bson.A{survey_vo.StatusPending, survey_vo.StatusRejected}}}},
+					// 外れ値の指摘が未確認のアンケートは店舗の統計と同じく集計に含めない。
+					{Key: "anomalyPending", Value: bson.D{{Key: "$ne", Value: true}}},
+				}}},
+				{{Key: "$group", Value: bson.D{
+					{Key: "_id", Value: nil},
+					{Key: "surveyCount", Value: bson.D{{Key: "$sum", Value: 1}}},
+					{Key: "helpfulCount", Value: bson.D{{Key: "$sum", Value: "$helpfulCount"}}},
+					{Key: "avgRating", Value: bson.D{{Key: "$avg", Value: "$rating"}}},
+					{Key: "avgEarning", Value: bson.D{{Key: "$avg", Value: "$averageEarning"}}},
+					{Key: "avgWaitTime", Value: bson.D{{Key: "$avg", Value: "$waitTimeHours"}}},
+					{Key: "lastSurveyedAt", Value: bson.D{{Key: "$max", Value: "$createdAt"}}},
+					// visitedPeriod は "YYYY-MM" 形式のため、文字列の最大値が最新の稼働時期になる。
+					{Key: "latestVisit", Value: bson.D{{Key: "$max", Value: "$visitedPeriod"}}},
+				}}},
+			}},
+			{Key: "as", Value: "comparison"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []comparisonDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	result := make([]store_domain.Comparison, 0, len(docs))
+	for _, doc := range docs {
+		comparison, err := doc.toComparison()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comparison)
+	}
+	return result, nil
+}
+
 // findMany は共有の検索ロジック。フィルター + ページングでカーソルを走査し、VO に復元する。
 // 途中で VO 生成に失敗した場合はそのままエラーを返して早期終了する。
 func (r *Repo) findMany(ctx context.Context, filter bson.M, page common_vo.Pagination) ([]*store_domain.Store, error) {
-	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	return r.findSorted(ctx, filter, bson.D{{Key: "createdAt", Value: -1}}, page)
+}
+
+func (r *Repo) findSorted(ctx context.Context, filter bson.M, sort bson.D, page common_vo.Pagination) ([]*store_domain.Store, error) {
+	opts := options.Find().SetSort(sort)
 	if !page.IsZero() {
 		opts.SetSkip(int64(page.Offset()))
 		opts.SetLimit(int64(page.Limit()))
@@ -221,6 +525,7 @@ type document struct {
 	ID            primitive.ObjectID     `bson:"_id"`
 	Name          string                 `bson:"name"`
 	BranchName    *string                `bson:"branchName,omitempty"`
+	SearchKey     string                 `bson:"searchKey"`
 	Prefecture    string                 `bson:"prefecture"`
 	Area          *string                `bson:"area,omitempty"`
 	Industry      string                 `bson:"industry"`
@@ -228,16 +533,84 @@ type document struct {
 	UnitPrice     *string                `bson:"unitPrice,omitempty"`
 	BusinessHours *businessHoursDocument `bson:"businessHours,omitempty"`
 	AverageRating float64                `bson:"averageRating"`
+	Stats         statsDocument          `bson:"stats"`
 	CreatedAt     time.Time              `bson:"createdAt"`
 	UpdatedAt     time.Time              `bson:"updatedAt"`
 	DeletedAt     *time.Time             `bson:"deletedAt,omitempty"`
 }
 
+// comparisonDocument は店舗ドキュメントに比較用の集計結果を付けたもの。アンケートがない場合 Comparison は空になる。
+type comparisonDocument struct {
+	document   `bson:",inline"`
+	Comparison []comparisonStatsDocument `bson:"comparison"`
+}
+
+type comparisonStatsDocument struct {
+	statsDocument `bson:",inline"`
+	LatestVisit   *string `bson:"latestVisit"`
+}
+
+func (d *comparisonDocument) toComparison() (store_domain.Comparison, error) {
+	entity, err := d.toEntity()
+	if err != nil {
+		return store_domain.Comparison{}, err
+	}
+	comparison := store_domain.Comparison{Store: entity}
+	if len(d.Comparison) == 0 {
+		return comparison, nil
+	}
+
+	agg := d.Comparison[0]
+	stats, err := store_vo.NewStats(agg.SurveyCount, agg.HelpfulCount, agg.AvgRating, agg.AvgEarning, agg.AvgWaitTime, agg.LastSurveyedAt)
+	if err != nil {
+		return store_domain.Comparison{}, err
+	}
+	comparison.Stats = stats
+	if agg.LatestVisit != nil {
+		visited, err := survey_vo.NewVisitedPeriod(*agg.LatestVisit)
+		if err != nil {
+			return store_domain.Comparison{}, err
+		}
+		latest := visited.Value()
+		comparison.LatestVisit = &latest
+	}
+	return comparison, nil
+}
+
 type businessHoursDocument struct {
 	Open  string `bson:"open"`
 	Close string `bson:"close"`
 }
 
+// statsDocument は承認済みアンケートから集計した統計。旧メンテナンススクリプトと同じフィールド名で保持する。
+// EarningSortKey は平均稼ぎ順の並び替え用に avgEarning の null を 0 に置き換えた値。
+// カーソルの境の条件は null に一致しないため、並び替えに使う項目は必ず数値で保存する。
+type statsDocument struct {
+	SurveyCount    int        `bson:"surveyCount"`
+	HelpfulCount   int        `bson:"helpfulCount"`
+	AvgRating      *float64   `bson:"avgRating"`
+	AvgEarning     *float64   `bson:"avgEarning"`
+	AvgWaitTime    *float64   `bson:"avgWaitTime"`
+	LastSurveyedAt *time.Time `bson:"lastSurveyedAt"`
+	EarningSortKey float64    `bson:"earningSortKey"`
+}
+
+func newStatsDocument(stats store_vo.Stats) statsDocument {
+	var earning float64
+	if avg := stats.AvgEarning(); avg != nil {
+		earning = *avg
+	}
+	return statsDocument{
+		EarningSortKey: earning,
+		SurveyCount:    stats.SurveyCount(),
+		HelpfulCount:   stats.HelpfulCount(),
+		AvgRating:      stats.AvgRating(),
+		AvgEarning:     stats.AvgEarning(),
+		AvgWaitTime:    stats.AvgWaitTime(),
+		LastSurveyedAt: stats.LastSurveyedAt(),
+	}
+}
+
 func newDocument(entity *store_domain.Store) (*document, error) {
 	oid, err := primitive.ObjectIDFromHex(entity.ID().Value())
 	if err != nil {
@@ -247,9 +620,11 @@ func newDocument(entity *store_domain.Store) (*document, error) {
 	doc := &document{
 		ID:            oid,
 		Name:          entity.Name().Value(),
+		SearchKey:     store_vo.SearchKey(entity.Name(), entity.BranchName()),
 		Prefecture:    entity.Prefecture().Value(),
 		Industry:      entity.Industry().Value(),
 		AverageRating: entity.AverageRating().Value(),
+		Stats:         newStatsDocument(entity.Stats()),
 		CreatedAt:     entity.CreatedAt().Value(),
 		UpdatedAt:     entity.UpdatedAt().Value(),
 	}
@@ -341,6 +716,10 @@ func (d *document) toEntity() (*store_domain.Store, error) {
 		return nil, err
 	}
 	opts = append(opts, store_domain.WithAverageRating(avgRating))
+	// 統計は再集計で復旧できる派生データのため、不正な値が残っていても店舗の読み込みは止めない。
+	if stats, err := store_vo.NewStats(d.Stats.SurveyCount, d.Stats.HelpfulCount, d.Stats.AvgRating, d.Stats.AvgEarning, d.Stats.AvgWaitTime, d.Stats.LastSurveyedAt); err == nil {
+		opts = append(opts, store_domain.WithStats(stats))
+	}
 
 	createdAt, err := common_vo.NewTimestamp(d.CreatedAt)
 	if err != nil {
@@ -366,13 +745,30 @@ func (d *document) toEntity() (*store_domain.Store, error) {
 	return entity, nil
 }
 
-func buildSort(sortKey common_vo.SortKey) bson.D {
+// sortFields は並び順ごとの並び替え項目を返す。同じ値の店舗は更新の新しい順、さらに _id の順とする。
+func sortFields(sortKey common_vo.SortKey) []mongo_infra.SortField {
+	updatedAt := mongo_infra.SortField{Name: "updatedAt", Desc: true, Kind: mongo_infra.FieldTime}
+	id := mongo_infra.SortField{Name: "_id", Desc: true, Kind: mongo_infra.FieldObjectID}
 	switch sortKey.Value() {
 	case common_vo.SortHelpful:
-		return bson.D{{Key: "helpfulCount", Value: -1}, {Key: "updatedAt", Value: -1}}
+		return []mongo_infra.SortField{{Name: "stats.helpfulCount", Desc: true, Kind: mongo_infra.FieldNumber}, updatedAt, id}
 	case common_vo.SortEarning:
-		return bson.D{{Key: "averageEarningAgg", Value: -1}, {Key: "updatedAt", Value: -1}}
+		return []mongo_infra.SortField{{Name: "stats.earningSortKey", Desc: true, Kind: mongo_infra.FieldNumber}, updatedAt, id}
+	default:
+		return []mongo_infra.SortField{updatedAt, id}
+	}
+}
+
+// searchSortValue は sortFields の項目の値を検索結果のドキュメントから取り出す。
+func searchSortValue(doc document, field string) interface{} {
+	switch field {
+	case "stats.helpfulCount":
+		return doc.Stats.HelpfulCount
+	case "stats.earningSortKey":
+		return doc.Stats.EarningSortKey
+	case "updatedAt":
+		return doc.UpdatedAt
 	default:
-		return bson.D{{Key: "updatedAt", Value: -1}}
+		return doc.ID
 	}
 }
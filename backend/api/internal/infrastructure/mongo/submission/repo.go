@@ -0,0 +1,281 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	submission_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/submission"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	submission_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/submission"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ submission_domain.Repo = (*Repo)(nil)
+
+// Repo は MongoDB バックエンドの一般投稿リポジトリ。
+type Repo struct {
+	collection *mongo.Collection
+}
+
+// NewRepo は Mongo コレクションから Repo を組み立てる。
+// nil の場合は panic を発生させ、DI 段階で気付けるようにする。
+func NewRepo(col *mongo.Collection) *Repo {
+	if col == nil {
+		panic("mongo submission repo: collection is nil")
+	}
+	return &Repo{collection: col}
+}
+
+// EnsureIndexes はステータス別一覧用のインデックスを作成する。
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "receivedAt", Value: -1}},
+		Options: options.Index().SetName("status_receivedAt"),
+	})
+	return err
+}
+
+// Save は投稿を upsert する。
+func (r *Repo) Save(ctx context.Context, entity *submission_domain.Submission) error {
+	if entity == nil {
+		return errors.New("mongo submission repo: submission is nil")
+	}
+
+	doc, err := newDocument(entity)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": doc.ID}
+	opts := options.Replace().SetUpsert(true)
+	_, err = r.collection.ReplaceOne(ctx, filter, doc, opts)
+	return err
+}
+
+// SaveIfPending は保存済みの投稿が確認待ちの場合のみ置き換え、置き換えたかを返す。
+func (r *Repo) SaveIfPending(ctx context.Context, entity *submission_domain.Submission) (bool, error) {
+	if entity == nil {
+		return false, errors.New("mongo submission repo: submission is nil")
+	}
+
+	doc, err := newDocument(entity)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": doc.ID, "status": submission_vo.StatusPending}
+	res, err := r.collection.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// FindByID は投稿 ID で 1 件取得する。
+func (r *Repo) FindByID(ctx context.Context, id submission_vo.ID) (*submission_domain.Submission, error) {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	var doc document
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return doc.toEntity()
+}
+
+// Find は条件に合う投稿を受信日時の新しい順に返す。件数とセットで返す。
+func (r *Repo) Find(ctx context.Context, filter submission_domain.Filter, page common_vo.Pagination) ([]*submission_domain.Submission, int64, error) {
+	query := bson.M{}
+	if filter.Status != nil {
+		query["status"] = filter.Status.Value()
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "receivedAt", Value: -1}, {Key: "_id", Value: -1}})
+	if !page.IsZero() {
+		opts.SetSkip(int64(page.Offset()))
+		opts.SetLimit(int64(page.Limit()))
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []document
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, err
+	}
+
+	submissions := make([]*submission_domain.Submission, 0, len(docs))
+	for _, doc := range docs {
+		entity, err := doc.toEntity()
+		if err != nil {
+			return nil, 0, err
+		}
+		submissions = append(submissions, entity)
+	}
+	return submissions, total, nil
+}
+
+// submission ドキュメント構造
+type document struct {
+	ID              primitive.ObjectID  `bson:"_id"`
+	Payload         payloadDocument     `bson:"payload"`
+	ClientIP        string              `bson:"clientIp,omitempty"`
+	Status          string              `bson:"status"`
+	SurveyID        *primitive.ObjectID `bson:"surveyId,omitempty"`
+	ProcessedBy     string              `bson:"processedBy,omitempty"`
+	ProcessedAt     *time.Time          `bson:"processedAt,omitempty"`
+	RejectionReason string              `bson:"rejectionReason,omitempty"`
+	ReceivedAt      time.Time           `bson:"receivedAt"`
+}
+
+// payloadDocument は送信内容をリクエストの JSON と同じキー名で保存する。
+type payloadDocument struct {
+	StoreName              string   `bson:"storeName,omitempty"`
+	BranchName             string   `bson:"branchName,omitempty"`
+	Prefecture             string   `bson:"prefecture,omitempty"`
+	Industry               string   `bson:"industry,omitempty"`
+	StoreID                string   `bson:"storeId,omitempty"`
+	VisitedPeriod          string   `bson:"visitedPeriod"`
+	WorkType               string   `bson:"workType"`
+	Age                    int      `bson:"age"`
+	SpecScore              int      `bson:"specScore"`
+	WaitTimeHours          int      `bson:"waitTimeHours"`
+	AverageEarning         int      `bson:"averageEarning"`
+	Rating                 float64  `bson:"rating"`
+	CustomerComment        *string  `bson:"customerComment,omitempty"`
+	StaffComment           *string  `bson:"staffComment,omitempty"`
+	WorkEnvironmentComment *string  `bson:"workEnvironmentComment,omitempty"`
+	EtcComment             *string  `bson:"etcComment,omitempty"`
+	CastBack               *string  `bson:"castBack,omitempty"`
+	EmailAddress           *string  `bson:"emailAddress,omitempty"`
+	ImageURLs              []string `bson:"imageUrls,omitempty"`
+}
+
+func newDocument(entity *submission_domain.Submission) (*document, error) {
+	oid, err := primitive.ObjectIDFromHex(entity.ID().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	p := entity.Payload()
+	doc := &document{
+		ID: oid,
+		Payload: payloadDocument{
+			StoreName:              p.StoreName,
+			BranchName:             p.BranchName,
+			Prefecture:             p.Prefecture,
+			Industry:               p.Industry,
+			StoreID:                p.StoreID,
+			VisitedPeriod:          p.VisitedPeriod,
+			WorkType:               p.WorkType,
+			Age:                    p.Age,
+			SpecScore:              p.SpecScore,
+			WaitTimeHours:          p.WaitTimeHours,
+			AverageEarning:         p.AverageEarning,
+			Rating:                 p.Rating,
+			CustomerComment:        p.CustomerComment,
+			StaffComment:           p.StaffComment,
+			WorkEnvironmentComment: p.WorkEnvironmentComment,
+			EtcComment:             p.EtcComment,
+			CastBack:               p.CastBack,
+			EmailAddress:           p.EmailAddress,
+			ImageURLs:              p.ImageURLs,
+		},
+		ClientIP:        entity.ClientIP(),
+		Status:          entity.Status().Value(),
+		ProcessedBy:     entity.ProcessedBy(),
+		RejectionReason: entity.RejectionReason(),
+		ReceivedAt:      entity.ReceivedAt().Value(),
+	}
+	if surveyID := entity.SurveyID(); surveyID != nil {
+		sid, err := primitive.ObjectIDFromHex(surveyID.Value())
+		if err != nil {
+			return nil, err
+		}
+		doc.SurveyID = &sid
+	}
+	if processedAt := entity.ProcessedAt(); processedAt != nil {
+		t := processedAt.Value()
+		doc.ProcessedAt = &t
+	}
+	return doc, nil
+}
+
+func (d *document) toEntity() (*submission_domain.Submission, error) {
+	id, err := submission_vo.NewID(d.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	status, err := submission_vo.NewStatus(d.Status)
+	if err != nil {
+		return nil, err
+	}
+	receivedAt, err := common_vo.NewTimestamp(d.ReceivedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	p := d.Payload
+	payload := submission_domain.Payload{
+		StoreName:              p.StoreName,
+		BranchName:             p.BranchName,
+		Prefecture:             p.Prefecture,
+		Industry:               p.Industry,
+		StoreID:                p.StoreID,
+		VisitedPeriod:          p.VisitedPeriod,
+		WorkType:               p.WorkType,
+		Age:                    p.Age,
+		SpecScore:              p.SpecScore,
+		WaitTimeHours:          p.WaitTimeHours,
+		AverageEarning:         p.AverageEarning,
+		Rating:                 p.Rating,
+		CustomerComment:        p.CustomerComment,
+		StaffComment:           p.StaffComment,
+		WorkEnvironmentComment: p.WorkEnvironmentComment,
+		EtcComment:             p.EtcComment,
+		CastBack:               p.CastBack,
+		EmailAddress:           p.EmailAddress,
+		ImageURLs:              p.ImageURLs,
+	}
+
+	opts := []submission_domain.Option{
+		submission_domain.WithClientIP(d.ClientIP),
+		submission_domain.WithStatus(status),
+		submission_domain.WithRejectionReason(d.RejectionReason),
+		submission_domain.WithReceivedAt(receivedAt),
+	}
+	if d.SurveyID != nil {
+		surveyID, err := survey_vo.NewID(d.SurveyID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, submission_domain.WithSurveyID(surveyID))
+	}
+	if d.ProcessedAt != nil {
+		processedAt, err := common_vo.NewTimestamp(*d.ProcessedAt)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, submission_domain.WithProcessed(d.ProcessedBy, processedAt))
+	}
+
+	return submission_domain.NewSubmission(id, payload, opts...)
+}
@@ -3,13 +3,18 @@ package survey
 import (
 	"context"
 	"errors"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/sngm3741/makoto-club-services/api/internal/domain/fulltext"
 	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	"github.com/sngm3741/makoto-club-services/api/internal/domain/textnorm"
 	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
 	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	mongo_infra "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -35,6 +40,8 @@ func NewRepo(col *mongo.Collection) *Repo {
 }
 
 // Save はアンケートを Upsert する。Survey 側で timestamps を更新した後に保存する想定。
+// helpfulCount は IncrementHelpful だけが変更するため、保存済みの値を残し、新規作成時は 0 とする。
+// 読み込んでから保存するまでの間に投票されても、エンティティが持つ古い件数で上書きしない。
 func (r *Repo) Save(ctx context.Context, entity *survey_domain.Survey) error {
 	if entity == nil {
 		return errors.New("mongo survey repo: survey is nil")
@@ -45,21 +52,45 @@ func (r *Repo) Save(ctx context.Context, entity *survey_domain.Survey) error {
 		return err
 	}
 
+	// ドキュメントを置き換えつつ helpfulCount だけ既存の値を引き継ぐため、パイプライン更新の $replaceWith を使う。
+	// コメントなどが "$" で始まっても式として解釈されないよう、保存する値は $literal で包む。
 	filter := bson.M{"_id": doc.ID}
-	opts := options.Replace().SetUpsert(true)
-	_, err = r.collection.ReplaceOne(ctx, filter, doc, opts)
+	update := mongo.Pipeline{
+		{{Key: "$replaceWith", Value: bson.M{"$mergeObjects": bson.A{
+			bson.M{"$literal": doc},
+			bson.M{"helpfulCount": bson.M{"$ifNull": bson.A{"$helpfulCount", 0}}},
+		}}}},
+	}
+	_, err = r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
 	return err
 }
 
 // FindByID はアンケート ID から 1 件取得する。ソフトデリートは除外。
 func (r *Repo) FindByID(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": oid, "deletedAt": bson.M{"$exists": false}})
+}
+
+// FindPublishedByID は承認済みのアンケートを ID から 1 件取得する。
+func (r *Repo) FindPublishedByID(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
 	oid, err := primitive.ObjectIDFromHex(id.Value())
 	if err != nil {
 		return nil, err
 	}
 	filter := bson.M{"_id": oid, "deletedAt": bson.M{"$exists": false}}
+	publishedOnly(filter)
+	return r.findOne(ctx, filter)
+}
+
+func (r *Repo) findOne(ctx context.Context, filter bson.M) (*survey_domain.Survey, error) {
 	var doc document
 	if err := r.collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return doc.toEntity()
@@ -72,36 +103,324 @@ func (r *Repo) FindByStore(ctx context.Context, storeID store_vo.ID, sort common
 		return nil, 0, err
 	}
 	filter := bson.M{"storeId": oid, "deletedAt": bson.M{"$exists": false}}
+	publishedOnly(filter)
 	return r.findMany(ctx, filter, sort, page)
 }
 
 // FindByPrefecture は店舗都道府県をキーに検索する。
 func (r *Repo) FindByPrefecture(ctx context.Context, pref store_vo.Prefecture, sort common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
 	filter := bson.M{"storePrefecture": pref.Value(), "deletedAt": bson.M{"$exists": false}}
+	publishedOnly(filter)
 	return r.findMany(ctx, filter, sort, page)
 }
 
 // FindAdmin は管理画面向けにフィルタ付きで一覧を返す。
-func (r *Repo) FindAdmin(ctx context.Context, filter survey_domain.AdminFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
+func (r *Repo) FindAdmin(ctx context.Context, filter survey_domain.AdminFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, common_vo.PageInfo, error) {
 	query := bson.M{"deletedAt": bson.M{"$exists": false}}
+	if filter.StoreID != nil {
+		oid, err := primitive.ObjectIDFromHex(filter.StoreID.Value())
+		if err != nil {
+			return nil, common_vo.PageInfo{}, err
+		}
+		query["storeId"] = oid
+	}
 	if filter.Prefecture != nil {
 		query["storePrefecture"] = filter.Prefecture.Value()
 	}
 	if filter.Industry != nil {
 		query["storeIndustry"] = filter.Industry.Value()
 	}
-	if kw := strings.TrimSpace(filter.Keyword); kw != "" {
-		reg := bson.M{"$regex": kw, "$options": "i"}
-		query["$or"] = []bson.M{
-			{"storeName": reg},
-			{"storeBranch": reg},
+	// 正規化すると検索できる文字が残らない検索語（記号のみなど）は条件に含めない。
+	if key := textnorm.Normalize(filter.Keyword); key != "" {
+		query["storeSearchKey"] = primitive.Regex{Pattern: regexp.QuoteMeta(key)}
+	}
+	if filter.Status != nil {
+		if filter.Status.IsPublished() {
+			publishedOnly(query)
+		} else {
+			query["status"] = filter.Status.Value()
+		}
+	}
+	if filter.PublishedOnly {
+		publishedOnly(query)
+	}
+	if filter.PendingAnomalies {
+		query["anomalyPending"] = true
+	}
+	applyAnswerConditions(query, filter)
+	if search := textSearch(filter.Text); search != "" {
+		// 一致度の順はドキュメントの値で境を表せないため、全文検索はページ番号でのみ取得する。
+		if page.UsesCursor() {
+			return nil, common_vo.PageInfo{}, common_vo.ErrInvalidCursor
+		}
+		query["$text"] = bson.M{"$search": search, "$diacriticSensitive": true}
+		order := append(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}, buildSort(sort)...)
+		surveys, total, err := r.findSorted(ctx, query, order, page)
+		if err != nil {
+			return nil, common_vo.PageInfo{}, err
+		}
+		return surveys, common_vo.PageInfo{Total: &total}, nil
+	}
+	return r.findPage(ctx, query, sort, page)
+}
+
+// findPage は一覧を 1 ページ分取得し、前後のページを取得するカーソルを返す。
+// ページ番号で指定した場合は件数を数え、カーソルで指定した場合は数えずにカーソルの位置から取得する。
+func (r *Repo) findPage(ctx context.Context, filter bson.M, sortKey common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, common_vo.PageInfo, error) {
+	fields := sortFields(sortKey)
+	var info common_vo.PageInfo
+	opts := options.Find()
+	if page.UsesCursor() {
+		cursor := page.Cursor()
+		if !cursor.SortKey().Equals(sortKey) {
+			return nil, info, common_vo.ErrInvalidCursor
+		}
+		keyset, err := mongo_infra.KeysetCondition(fields, cursor)
+		if err != nil {
+			return nil, info, err
+		}
+		filter = bson.M{"$and": bson.A{filter, keyset}}
+		opts.SetSort(mongo_infra.SortDocument(fields, cursor.Backward()))
+	} else {
+		total, err := r.collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, info, err
+		}
+		info.Total = &total
+		opts.SetSort(mongo_infra.SortDocument(fields, false))
+		opts.SetSkip(int64(page.Offset()))
+	}
+	if !page.IsZero() {
+		// 次のページがあるかを判定するため 1 件多く取得する。
+		opts.SetLimit(int64(page.Limit() + 1))
+	}
+
+	cur, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, info, err
+	}
+	defer cur.Close(ctx)
+
+	var docs []document
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, info, err
+	}
+	docs, hasNext, hasPrev := mongo_infra.TrimPage(docs, page)
+	info.Next, info.Prev = mongo_infra.PageCursors(docs, sortKey, fields, hasNext, hasPrev, documentSortValue)
+
+	surveys := make([]*survey_domain.Survey, 0, len(docs))
+	for _, doc := range docs {
+		entity, err := doc.toEntity()
+		if err != nil {
+			return nil, info, err
+		}
+		surveys = append(surveys, entity)
+	}
+	return surveys, info, nil
+}
+
+// applyAnswerConditions は回答の値・稼働時期・画像の有無に関する条件を追加する。
+func applyAnswerConditions(query bson.M, filter survey_domain.AdminFilter) {
+	for field, r := range map[string]common_vo.Range{
+		"age":            filter.Age,
+		"specScore":      filter.SpecScore,
+		"averageEarning": filter.AverageEarning,
+		"waitTimeHours":  filter.WaitTimeHours,
+		"rating":         filter.Rating,
+	} {
+		if cond := mongo_infra.RangeCondition(r); cond != nil {
+			query[field] = cond
+		}
+	}
+	if len(filter.WorkTypes) > 0 {
+		workTypes := make(bson.A, 0, len(filter.WorkTypes))
+		for _, w := range filter.WorkTypes {
+			workTypes = append(workTypes, w.Value())
+		}
+		query["workType"] = bson.M{"$in": workTypes}
+	}
+	// visitedPeriod は "2006-01" 形式で保存しているため、文字列の大小で月の前後を比べられる。
+	period := bson.M{}
+	if !filter.VisitedFrom.IsZero() {
+		period["$gte"] = filter.VisitedFrom.Value().Format("2006-01")
+	}
+	if !filter.VisitedTo.IsZero() {
+		period["$lte"] = filter.VisitedTo.Value().Format("2006-01")
+	}
+	if len(period) > 0 {
+		query["visitedPeriod"] = period
+	}
+	if filter.HasImages != nil {
+		query["imageUrls.0"] = bson.M{"$exists": *filter.HasImages}
+	}
+}
+
+// textSearch は全文検索の語句を $text の検索文字列に変換する。検索できる語がない場合は空文字を返す。
+// 分かち書きした語をそれぞれ引用符で囲み、すべての語を含むドキュメントに限定する。
+// 濁点・半濁点の有無で別の語になる日本語のため、$diacriticSensitive を併せて指定する。
+func textSearch(text string) string {
+	terms := fulltext.QueryTerms(text)
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		quoted = append(quoted, `"`+term+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// EnsureIndexes は一覧の絞り込みと、コメントの全文検索に使うインデックスを作成する。
+// 全文検索の語は保存時に分かち書きしているため、MongoDB 側では言語ごとの語幹処理を行わない。
+// 回答の値の絞り込みは、一致条件になりやすい項目を先頭に、範囲条件の項目と並び順の createdAt を続ける。
+// あわせて helpfulCount を持たない既存ドキュメントに 0 を補う。
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	if err := r.backfillHelpfulCount(ctx); err != nil {
+		return err
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "commentIndex", Value: "text"}},
+			Options: options.Index().SetName("commentIndex_text").SetDefaultLanguage("none"),
+		},
+		{
+			Keys:    bson.D{{Key: "storePrefecture", Value: 1}, {Key: "storeIndustry", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("storePrefecture_storeIndustry_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "workType", Value: 1}, {Key: "age", Value: 1}, {Key: "specScore", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("workType_age_specScore_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "visitedPeriod", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("visitedPeriod_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "averageEarning", Value: -1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("averageEarning_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "rating", Value: -1}, {Key: "waitTimeHours", Value: 1}, {Key: "createdAt", Value: -1}},
+			Options: options.Index().SetName("rating_waitTimeHours_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "duplicateKeys", Value: 1}, {Key: "createdAt", Value: 1}},
+			Options: options.Index().SetName("duplicateKeys_createdAt"),
+		},
+		{
+			Keys:    bson.D{{Key: "storeId", Value: 1}, {Key: "visitedPeriod", Value: 1}, {Key: "createdAt", Value: 1}},
+			Options: options.Index().SetName("storeId_visitedPeriod_createdAt"),
+		},
+	})
+	return err
+}
+
+// backfillHelpfulCount は「参考になった」の導入前に作られ helpfulCount を持たないドキュメントに 0 を設定する。
+// 参考になった順のカーソルは欠けたフィールドに一致しないため、欠けたままだと 2 ページ目以降から漏れる。
+func (r *Repo) backfillHelpfulCount(ctx context.Context) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"helpfulCount": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"helpfulCount": 0}},
+	)
+	return err
+}
+
+// ReindexSearch は論理削除済みを含む全アンケートについて、コメントの索引・店舗名の検索キー・重複検出のキーを作り直し、更新した件数を返す。
+// 索引・検索キーを持たない既存ドキュメントの移行や、分かち書き・正規化・署名の方法を変えた場合に使う。
+func (r *Repo) ReindexSearch(ctx context.Context) (int64, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	for cursor.Next(ctx) {
+		var doc document
+		if err := cursor.Decode(&doc); err != nil {
+			return updated, err
+		}
+		entity, err := doc.toEntity()
+		if err != nil {
+			return updated, err
+		}
+		index := entity.CommentSearchIndex()
+		storeKey := store_vo.SearchKey(entity.StoreName(), entity.StoreBranch())
+		duplicateKeys := entity.DuplicateKeys()
+		if index == doc.CommentIndex && storeKey == doc.StoreSearchKey && slices.Equal(duplicateKeys, doc.DuplicateKeys) {
+			continue
+		}
+		set := bson.M{"storeSearchKey": storeKey}
+		unset := bson.M{}
+		if index != "" {
+			set["commentIndex"] = index
+		} else {
+			unset["commentIndex"] = ""
+		}
+		if len(duplicateKeys) > 0 {
+			set["duplicateKeys"] = duplicateKeys
+		} else {
+			unset["duplicateKeys"] = ""
+		}
+		update := bson.M{"$set": set}
+		if len(unset) > 0 {
+			update["$unset"] = unset
+		}
+		if _, err := r.collection.UpdateByID(ctx, doc.ID, update); err != nil {
+			return updated, err
 		}
+		updated++
 	}
-	return r.findMany(ctx, query, sort, page)
+	return updated, cursor.Err()
 }
 
-// Delete はアンケートを物理削除する。
-func (r *Repo) Delete(ctx context.Context, id survey_vo.ID) error {
+// FindDuplicateCandidates は作成日時が query.From 以上 query.To 以下の論理削除されていないアンケートのうち、
+// 重複の候補になるものを作成日時の古い順に返す。duplicateKeys のいずれかが一致するもの、または店舗・稼働時期が同じものを対象とし、
+// 期間内の全件を読み込まずに索引で絞り込む。
+func (r *Repo) FindDuplicateCandidates(ctx context.Context, query survey_domain.DuplicateQuery) ([]*survey_domain.Survey, error) {
+	storeID, err := primitive.ObjectIDFromHex(query.StoreID.Value())
+	if err != nil {
+		return nil, err
+	}
+	or := bson.A{bson.M{"storeId": storeID, "visitedPeriod": query.VisitedPeriod.Value().Format("2006-01")}}
+	if len(query.Keys) > 0 {
+		or = append(or, bson.M{"duplicateKeys": bson.M{"$in": query.Keys}})
+	}
+	filter := bson.M{
+		"deletedAt": bson.M{"$exists": false},
+		"createdAt": bson.M{"$gte": query.From, "$lte": query.To},
+		"$or":       or,
+	}
+	surveys, _, err := r.findSorted(ctx, filter, bson.D{{Key: "createdAt", Value: 1}}, common_vo.Pagination{})
+	return surveys, err
+}
+
+// publishedOnly は承認済みのアンケートに限定する条件を追加する。
+// status を持たない既存ドキュメントは承認済みとして扱うため、非公開ステータスを除外する形で指定する。
+func publishedOnly(filter bson.M) {
+	filter["status"] = bson.M{"$nin": bson.A{survey_vo.StatusPending, survey_vo.StatusRejected}}
+}
+
+// statsEligible は統計・ランキングの集計対象に限定する条件を追加する。
+// 承認済みのアンケートのうち、外れ値の指摘が未確認のものを除く。
+func statsEligible(filter bson.M) {
+	publishedOnly(filter)
+	filter["anomalyPending"] = bson.M{"$ne": true}
+}
+
+// FindByIDIncludingDeleted はソフトデリート済みも含めてアンケートを 1 件取得する。復元・完全削除で使う。
+func (r *Repo) FindByIDIncludingDeleted(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+// FindDeleted は論理削除されたアンケートを削除日時の新しい順に取得する。
+func (r *Repo) FindDeleted(ctx context.Context, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
+	return r.findSorted(ctx, bson.M{"deletedAt": bson.M{"$exists": true}}, bson.D{{Key: "deletedAt", Value: -1}}, page)
+}
+
+// Purge はアンケートドキュメントを物理削除する。通常の削除は MarkDeleted + Save による論理削除を使う。
+func (r *Repo) Purge(ctx context.Context, id survey_vo.ID) error {
 	oid, err := primitive.ObjectIDFromHex(id.Value())
 	if err != nil {
 		return err
@@ -110,13 +429,490 @@ func (r *Repo) Delete(ctx context.Context, id survey_vo.ID) error {
 	return err
 }
 
+// IncrementHelpful は helpfulCount を $inc で増減する。Save は helpfulCount を書き換えないため、件数の変更はこのメソッドだけが行う。
+// 減算時は件数が delta 以上のドキュメントのみを対象にし、0 未満にならないようにする。
+func (r *Repo) IncrementHelpful(ctx context.Context, id survey_vo.ID, delta int) error {
+	oid, err := primitive.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": oid}
+	if delta < 0 {
+		filter["helpfulCount"] = bson.M{"$gte": -delta}
+	}
+	_, err = r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"helpfulCount": delta}})
+	return err
+}
+
+// CountByStore は店舗に紐づくアンケートの件数を返す。承認待ち・非承認と、ゴミ箱にある論理削除済みのものも含む。
+func (r *Repo) CountByStore(ctx context.Context, storeID store_vo.ID) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(storeID.Value())
+	if err != nil {
+		return 0, err
+	}
+	return r.collection.CountDocuments(ctx, bson.M{"storeId": oid})
+}
+
+// CountLiveByStore は店舗に紐づく論理削除されていないアンケートの件数を返す。承認待ち・非承認のものも含む。
+func (r *Repo) CountLiveByStore(ctx context.Context, storeID store_vo.ID) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(storeID.Value())
+	if err != nil {
+		return 0, err
+	}
+	return r.collection.CountDocuments(ctx, bson.M{"storeId": oid, "deletedAt": bson.M{"$exists": false}})
+}
+
+// SoftDeleteByStore は店舗に紐づく論理削除されていないアンケートを at の日時で論理削除する。
+// 店舗と同じ日時を指定しておくことで、RestoreByStore で同時に削除されたものだけを復元できる。
+func (r *Repo) SoftDeleteByStore(ctx context.Context, storeID store_vo.ID, at common_vo.Timestamp) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(storeID.Value())
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"storeId": oid, "deletedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deletedAt": at.Value(), "updatedAt": at.Value()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// RestoreByStore は店舗に紐づくアンケートのうち、deletedAt の日時で論理削除されたものを復元する。
+// 店舗削除より前に個別に削除されたアンケートは復元しない。
+func (r *Repo) RestoreByStore(ctx context.Context, storeID store_vo.ID, deletedAt, at common_vo.Timestamp) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(storeID.Value())
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.collection.UpdateMany(ctx,
+		bson.M{"storeId": oid, "deletedAt": deletedAt.Value()},
+		bson.M{"$unset": bson.M{"deletedAt": ""}, "$set": bson.M{"updatedAt": at.Value()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// ReassignStore は from に紐づくアンケートを to の店舗へ付け替え、複製している店舗情報も置き換える。
+// 付け替え元の店舗が完全削除されても参照が残らないよう、論理削除済みのアンケートも対象にする。
+func (r *Repo) ReassignStore(ctx context.Context, from store_vo.ID, to survey_domain.StoreSnapshot, at common_vo.Timestamp) (int64, error) {
+	fromOID, err := primitive.ObjectIDFromHex(from.Value())
+	if err != nil {
+		return 0, err
+	}
+	update, err := storeSnapshotUpdate(to, at)
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.collection.UpdateMany(ctx, bson.M{"storeId": fromOID}, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// PurgeDeletedByStore は店舗に紐づく論理削除済みのアンケートを物理削除する。店舗の完全削除に合わせて使う。
+func (r *Repo) PurgeDeletedByStore(ctx context.Context, storeID store_vo.ID) (int64, error) {
+	oid, err := primitive.ObjectIDFromHex(storeID.Value())
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.collection.DeleteMany(ctx, bson.M{"storeId": oid, "deletedAt": bson.M{"$exists": true}})
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// SyncStoreSnapshot は店舗に紐づくアンケートのうち、複製している店舗情報が snapshot と異なるものを更新する。
+// 論理削除済みのアンケートも復元時に古い情報が残らないよう対象にする。
+func (r *Repo) SyncStoreSnapshot(ctx context.Context, snapshot survey_domain.StoreSnapshot, at common_vo.Timestamp) (int64, error) {
+	filter, err := storeDriftFilter(snapshot)
+	if err != nil {
+		return 0, err
+	}
+	update, err := storeSnapshotUpdate(snapshot, at)
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// CountStoreSnapshotDrift は複製している店舗情報が snapshot と異なるアンケートの件数を返す。
+func (r *Repo) CountStoreSnapshotDrift(ctx context.Context, snapshot survey_domain.StoreSnapshot) (int64, error) {
+	filter, err := storeDriftFilter(snapshot)
+	if err != nil {
+		return 0, err
+	}
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// storeDriftFilter は店舗情報の複製が snapshot と一致しないアンケートを絞り込む条件を組み立てる。
+// $ne はフィールドが存在しないドキュメントにも一致するため、必須項目の欠損も検出できる。
+func storeDriftFilter(snapshot survey_domain.StoreSnapshot) (bson.M, error) {
+	storeID, err := primitive.ObjectIDFromHex(snapshot.ID.Value())
+	if err != nil {
+		return nil, err
+	}
+	conditions := bson.A{
+		bson.M{"storeName": bson.M{"$ne": snapshot.Name.Value()}},
+		bson.M{"storePrefecture": bson.M{"$ne": snapshot.Prefecture.Value()}},
+		bson.M{"storeIndustry": bson.M{"$ne": snapshot.Industry.Value()}},
+	}
+	// 任意項目は未設定の場合、フィールドが残っていれば不一致とする。
+	missing := bson.M{"$exists": true}
+	if snapshot.Branch != nil {
+		conditions = append(conditions, bson.M{"storeBranchName": bson.M{"$ne": snapshot.Branch.Value()}})
+	} else {
+		conditions = append(conditions, bson.M{"storeBranchName": missing})
+	}
+	if snapshot.Area != nil {
+		conditions = append(conditions, bson.M{"storeArea": bson.M{"$ne": snapshot.Area.Value()}})
+	} else {
+		conditions = append(conditions, bson.M{"storeArea": missing})
+	}
+	if snapshot.Genre != nil {
+		conditions = append(conditions, bson.M{"storeGenre": bson.M{"$ne": snapshot.Genre.Value()}})
+	} else {
+		conditions = append(conditions, bson.M{"storeGenre": missing})
+	}
+	return bson.M{"storeId": storeID, "$or": conditions}, nil
+}
+
+// StoreStats は店舗に紐づく承認済みアンケートを集計する。アンケートがない場合はゼロ値を返す。
+func (r *Repo) StoreStats(ctx context.Context, storeID store_vo.ID) (store_vo.Stats, error) {
+	oid, err := primitive.ObjectIDFromHex(storeID.Value())
+	if err != nil {
+		return store_vo.Stats{}, err
+	}
+	stats, err := r.aggregateStoreStats(ctx, bson.M{"storeId": oid})
+	if err != nil {
+		return store_vo.Stats{}, err
+	}
+	return stats[storeID.Value()], nil
+}
+
+// AllStoreStats は承認済みアンケートを店舗ごとに集計し、店舗 ID をキーにして返す。
+// アンケートが 1 件もない店舗は含まれない。
+func (r *Repo) AllStoreStats(ctx context.Context) (map[string]store_vo.Stats, error) {
+	return r.aggregateStoreStats(ctx, bson.M{})
+}
+
+// storeStatsDocument は店舗ごとの集計結果。
+type storeStatsDocument struct {
+	StoreID        primitive.ObjectID `bson:"_id"`
+	SurveyCount    int                `bson:"surveyCount"`
+	HelpfulCount   int                `bson:"helpfulCount"`
+	AvgRating      *float64           `bson:"avgRating"`
+	AvgEarning     *float64           `bson:"avgEarning"`
+	AvgWaitTime    *float64           `bson:"avgWaitTime"`
+	LastSurveyedAt *time.Time         `bson:"lastSurveyedAt"`
+}
+
+// aggregateStoreStats は filter に一致する公開中のアンケートを店舗ごとに集計する。
+func (r *Repo) aggregateStoreStats(ctx context.Context, filter bson.M) (map[string]store_vo.Stats, error) {
+	filter["deletedAt"] = bson.M{"$exists": false}
+	statsEligible(filter)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$storeId"},
+			{Key: "surveyCount", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "helpfulCount", Value: bson.D{{Key: "$sum", Value: "$helpfulCount"}}},
+			{Key: "avgRating", Value: bson.D{{Key: "$avg", Value: "$rating"}}},
+			{Key: "avgEarning", Value: bson.D{{Key: "$avg", Value: "$averageEarning"}}},
+			{Key: "avgWaitTime", Value: bson.D{{Key: "$avg", Value: "$waitTimeHours"}}},
+			{Key: "lastSurveyedAt", Value: bson.D{{Key: "$max", Value: "$createdAt"}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []storeStatsDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]store_vo.Stats, len(docs))
+	for _, doc := range docs {
+		stats, err := store_vo.NewStats(doc.SurveyCount, doc.HelpfulCount, doc.AvgRating, doc.AvgEarning, doc.AvgWaitTime, doc.LastSurveyedAt)
+		if err != nil {
+			return nil, err
+		}
+		result[doc.StoreID.Hex()] = stats
+	}
+	return result, nil
+}
+
+// MonthlyTrends は filter に一致する承認済みアンケートを稼働時期の月ごとに集計し、古い順に返す。
+// アンケートがない月は含まれない。visitedPeriod は "YYYY-MM" 形式の文字列のため、範囲は文字列比較で絞り込む。
+func (r *Repo) MonthlyTrends(ctx context.Context, filter survey_domain.TrendFilter) ([]survey_domain.PeriodAggregate, error) {
+	match := bson.M{"deletedAt": bson.M{"$exists": false}}
+	statsEligible(match)
+	if filter.StoreID != nil {
+		oid, err := primitive.ObjectIDFromHex(filter.StoreID.Value())
+		if err != nil {
+			return nil, err
+		}
+		match["storeId"] = oid
+	}
+	if filter.Prefecture != nil {
+		match["storePrefecture"] = filter.Prefecture.Value()
+	}
+	if filter.Area != nil {
+		match["storeArea"] = filter.Area.Value()
+	}
+	if filter.Industry != nil {
+		match["storeIndustry"] = filter.Industry.Value()
+	}
+	period := bson.M{}
+	if !filter.From.IsZero() {
+		period["$gte"] = filter.From.Value().Format("2006-01")
+	}
+	if !filter.To.IsZero() {
+		period["$lte"] = filter.To.Value().Format("2006-01")
+	}
+	if len(period) > 0 {
+		match["visitedPeriod"] = period
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$visitedPeriod"},
+			{Key: "surveyCount", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "avgEarning", Value: bson.D{{Key: "$avg", Value: "$averageEarning"}}},
+			{Key: "avgRating", Value: bson.D{{Key: "$avg", Value: "$rating"}}},
+			{Key: "avgWaitTime", Value: bson.D{{Key: "$avg", Value: "$waitTimeHours"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []periodAggregateDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	result := make([]survey_domain.PeriodAggregate, 0, len(docs))
+	for _, doc := range docs {
+		period, err := survey_vo.NewVisitedPeriod(doc.Period)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, survey_domain.PeriodAggregate{
+			Period:      period,
+			SurveyCount: doc.SurveyCount,
+			AvgEarning:  doc.AvgEarning,
+			AvgRating:   doc.AvgRating,
+			AvgWaitTime: doc.AvgWaitTime,
+		})
+	}
+	return result, nil
+}
+
+// periodAggregateDocument は稼働時期ごとの集計結果。
+type periodAggregateDocument struct {
+	Period      string  `bson:"_id"`
+	SurveyCount int     `bson:"surveyCount"`
+	AvgEarning  float64 `bson:"avgEarning"`
+	AvgRating   float64 `bson:"avgRating"`
+	AvgWaitTime float64 `bson:"avgWaitTime"`
+}
+
+// EarningSamples は承認済みアンケートから稼ぎの推定に使う項目だけを取り出して返す。
+// 推定モデルの再構築で全件を読むため、必要なフィールドに絞って転送量を抑える。
+// 旧データなどで値オブジェクトに変換できないアンケートは推定の対象から外す。
+func (r *Repo) EarningSamples(ctx context.Context) ([]survey_domain.EarningSample, error) {
+	filter := bson.M{"deletedAt": bson.M{"$exists": false}}
+	statsEligible(filter)
+	projection := bson.M{
+		"storeId":         1,
+		"storePrefecture": 1,
+		"storeIndustry":   1,
+		"workType":        1,
+		"age":             1,
+		"specScore":       1,
+		"averageEarning":  1,
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []earningSampleDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	samples := make([]survey_domain.EarningSample, 0, len(docs))
+	for _, doc := range docs {
+		sample, err := doc.toSample()
+		if err != nil {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// NumericAnswers は filter に一致する集計対象のアンケートから、平均稼ぎと待機時間の回答を取り出す。
+func (r *Repo) NumericAnswers(ctx context.Context, filter survey_domain.AnswerFilter) (survey_domain.NumericAnswers, error) {
+	query := bson.M{"deletedAt": bson.M{"$exists": false}}
+	statsEligible(query)
+	if filter.StoreID != nil {
+		oid, err := primitive.ObjectIDFromHex(filter.StoreID.Value())
+		if err != nil {
+			return survey_domain.NumericAnswers{}, err
+		}
+		query["storeId"] = oid
+	}
+	if filter.Industry != nil {
+		query["storeIndustry"] = filter.Industry.Value()
+	}
+	if filter.ExcludeID != nil {
+		oid, err := primitive.ObjectIDFromHex(filter.ExcludeID.Value())
+		if err != nil {
+			return survey_domain.NumericAnswers{}, err
+		}
+		query["_id"] = bson.M{"$ne": oid}
+	}
+
+	projection := bson.M{"averageEarning": 1, "waitTimeHours": 1}
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetProjection(projection))
+	if err != nil {
+		return survey_domain.NumericAnswers{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		AverageEarning int `bson:"averageEarning"`
+		WaitTimeHours  int `bson:"waitTimeHours"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return survey_domain.NumericAnswers{}, err
+	}
+
+	answers := survey_domain.NumericAnswers{
+		AverageEarnings: make([]float64, 0, len(docs)),
+		WaitTimeHours:   make([]float64, 0, len(docs)),
+	}
+	for _, doc := range docs {
+		answers.AverageEarnings = append(answers.AverageEarnings, float64(doc.AverageEarning))
+		answers.WaitTimeHours = append(answers.WaitTimeHours, float64(doc.WaitTimeHours))
+	}
+	return answers, nil
+}
+
+// earningSampleDocument は EarningSamples で射影したアンケートの項目。
+type earningSampleDocument struct {
+	StoreID         primitive.ObjectID `bson:"storeId"`
+	StorePrefecture string             `bson:"storePrefecture"`
+	StoreIndustry   string             `bson:"storeIndustry"`
+	WorkType        string             `bson:"workType"`
+	Age             int                `bson:"age"`
+	SpecScore       int                `bson:"specScore"`
+	AverageEarning  int                `bson:"averageEarning"`
+}
+
+func (d earningSampleDocument) toSample() (survey_domain.EarningSample, error) {
+	var (
+		sample survey_domain.EarningSample
+		err    error
+	)
+	if sample.StoreID, err = store_vo.NewID(d.StoreID.Hex()); err != nil {
+		return sample, err
+	}
+	if sample.Prefecture, err = store_vo.NewPrefecture(d.StorePrefecture); err != nil {
+		return sample, err
+	}
+	if sample.Industry, err = store_vo.NewIndustry(d.StoreIndustry); err != nil {
+		return sample, err
+	}
+	if sample.WorkType, err = survey_vo.NewWorkType(d.WorkType); err != nil {
+		return sample, err
+	}
+	if sample.Age, err = survey_vo.NewAge(d.Age); err != nil {
+		return sample, err
+	}
+	if sample.SpecScore, err = survey_vo.NewSpecScore(d.SpecScore); err != nil {
+		return sample, err
+	}
+	if sample.AverageEarning, err = survey_vo.NewAverageEarning(d.AverageEarning); err != nil {
+		return sample, err
+	}
+	return sample, nil
+}
+
+// storeSnapshotUpdate は店舗情報の複製を置き換える更新ドキュメントを組み立てる。
+// 任意項目が未設定の場合はフィールドごと削除し、newDocument の omitempty と揃える。
+func storeSnapshotUpdate(snapshot survey_domain.StoreSnapshot, at common_vo.Timestamp) (bson.M, error) {
+	storeID, err := primitive.ObjectIDFromHex(snapshot.ID.Value())
+	if err != nil {
+		return nil, err
+	}
+	set := bson.M{
+		"storeId":         storeID,
+		"storeName":       snapshot.Name.Value(),
+		"storeSearchKey":  store_vo.SearchKey(snapshot.Name, snapshot.Branch),
+		"storePrefecture": snapshot.Prefecture.Value(),
+		"storeIndustry":   snapshot.Industry.Value(),
+		"updatedAt":       at.Value(),
+	}
+	unset := bson.M{}
+	if snapshot.Branch != nil {
+		set["storeBranchName"] = snapshot.Branch.Value()
+	} else {
+		unset["storeBranchName"] = ""
+	}
+	if snapshot.Area != nil {
+		set["storeArea"] = snapshot.Area.Value()
+	} else {
+		unset["storeArea"] = ""
+	}
+	if snapshot.Genre != nil {
+		set["storeGenre"] = snapshot.Genre.Value()
+	} else {
+		unset["storeGenre"] = ""
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
 // findMany は共通のカーソル処理。ドキュメントを VO に変換しつつスライス化する。
 func (r *Repo) findMany(ctx context.Context, filter bson.M, sortKey common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
+	return r.findSorted(ctx, filter, buildSort(sortKey), page)
+}
+
+func (r *Repo) findSorted(ctx context.Context, filter bson.M, sort bson.D, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
 	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, err
 	}
-	opts := options.Find().SetSort(buildSort(sortKey))
+	opts := options.Find().SetSort(sort)
 	if !page.IsZero() {
 		opts.SetSkip(int64(page.Offset()))
 		opts.SetLimit(int64(page.Limit()))
@@ -150,13 +946,34 @@ func (r *Repo) FindAll(ctx context.Context, sort common_vo.SortKey, page common_
 }
 
 func buildSort(sortKey common_vo.SortKey) bson.D {
+	return mongo_infra.SortDocument(sortFields(sortKey), false)
+}
+
+// sortFields は並び順ごとの並び替え項目を返す。同じ値のアンケートは新しい順、さらに _id の順とする。
+func sortFields(sortKey common_vo.SortKey) []mongo_infra.SortField {
+	createdAt := mongo_infra.SortField{Name: "createdAt", Desc: true, Kind: mongo_infra.FieldTime}
+	id := mongo_infra.SortField{Name: "_id", Desc: true, Kind: mongo_infra.FieldObjectID}
 	switch sortKey.Value() {
 	case common_vo.SortEarning:
-		return bson.D{{Key: "averageEarning", Value: -1}, {Key: "createdAt", Value: -1}}
+		return []mongo_infra.SortField{{Name: "averageEarning", Desc: true, Kind: mongo_infra.FieldNumber}, createdAt, id}
 	case common_vo.SortHelpful:
-		return bson.D{{Key: "helpfulCount", Value: -1}, {Key: "createdAt", Value: -1}}
+		return []mongo_infra.SortField{{Name: "helpfulCount", Desc: true, Kind: mongo_infra.FieldNumber}, createdAt, id}
+	default:
+		return []mongo_infra.SortField{createdAt, id}
+	}
+}
+
+// documentSortValue は sortFields の項目の値をドキュメントから取り出す。
+func documentSortValue(doc document, field string) interface{} {
+	switch field {
+	case "averageEarning":
+		return doc.AverageEarning
+	case "helpfulCount":
+		return doc.HelpfulCount
+	case "createdAt":
+		return doc.CreatedAt
 	default:
-		return bson.D{{Key: "createdAt", Value: -1}}
+		return doc.ID
 	}
 }
 
@@ -166,6 +983,7 @@ type document struct {
 	StoreID                primitive.ObjectID `bson:"storeId"`
 	StoreName              string             `bson:"storeName"`
 	StoreBranchName        *string            `bson:"storeBranchName,omitempty"`
+	StoreSearchKey         string             `bson:"storeSearchKey"`
 	StorePrefecture        string             `bson:"storePrefecture"`
 	StoreArea              *string            `bson:"storeArea,omitempty"`
 	StoreIndustry          string             `bson:"storeIndustry"`
@@ -181,14 +999,36 @@ type document struct {
 	StaffComment           *string            `bson:"staffComment,omitempty"`
 	WorkEnvironmentComment *string            `bson:"workEnvironmentComment,omitempty"`
 	EtcComment             *string            `bson:"etcComment,omitempty"`
+	CommentIndex           string             `bson:"commentIndex,omitempty"`
+	DuplicateKeys          []string           `bson:"duplicateKeys,omitempty"`
 	CastBack               *string            `bson:"castBack,omitempty"`
 	EmailAddress           *string            `bson:"emailAddress,omitempty"`
 	ImageURLs              []string           `bson:"imageUrls,omitempty"`
+	Status                 string             `bson:"status,omitempty"`
+	ReviewedBy             string             `bson:"reviewedBy,omitempty"`
+	ReviewedAt             *time.Time         `bson:"reviewedAt,omitempty"`
+	RejectionReason        string             `bson:"rejectionReason,omitempty"`
+	HelpfulCount           int                `bson:"helpfulCount"`
+	Anomalies              []anomalyDocument  `bson:"anomalies,omitempty"`
+	AnomaliesConfirmedBy   string             `bson:"anomaliesConfirmedBy,omitempty"`
+	AnomaliesConfirmedAt   *time.Time         `bson:"anomaliesConfirmedAt,omitempty"`
+	AnomalyPending         bool               `bson:"anomalyPending,omitempty"`
 	CreatedAt              time.Time          `bson:"createdAt"`
 	UpdatedAt              time.Time          `bson:"updatedAt"`
 	DeletedAt              *time.Time         `bson:"deletedAt,omitempty"`
 }
 
+// anomalyDocument は外れ値の指摘 1 件分。
+type anomalyDocument struct {
+	Field     string  `bson:"field"`
+	Reference string  `bson:"reference"`
+	Value     float64 `bson:"value"`
+	Median    float64 `bson:"median"`
+	Lower     float64 `bson:"lower"`
+	Upper     float64 `bson:"upper"`
+	Score     float64 `bson:"score"`
+}
+
 func newDocument(entity *survey_domain.Survey) (*document, error) {
 	id, err := primitive.ObjectIDFromHex(entity.ID().Value())
 	if err != nil {
@@ -203,6 +1043,7 @@ func newDocument(entity *survey_domain.Survey) (*document, error) {
 		ID:              id,
 		StoreID:         storeID,
 		StoreName:       entity.StoreName().Value(),
+		StoreSearchKey:  store_vo.SearchKey(entity.StoreName(), entity.StoreBranch()),
 		StorePrefecture: entity.StorePrefecture().Value(),
 		StoreIndustry:   entity.StoreIndustry().Value(),
 		VisitedPeriod:   entity.VisitedPeriod().Value().Format("2006-01"),
@@ -212,10 +1053,38 @@ func newDocument(entity *survey_domain.Survey) (*document, error) {
 		WaitTimeHours:   entity.WaitTime().Value(),
 		AverageEarning:  entity.AverageEarning().Value(),
 		Rating:          entity.Rating().Value(),
-		CreatedAt:       entity.CreatedAt().Value(),
-		UpdatedAt:       entity.UpdatedAt().Value(),
+		Status:          entity.Status().Value(),
+		ReviewedBy:      entity.ReviewedBy(),
+		RejectionReason: entity.RejectionReason(),
+		HelpfulCount:    entity.HelpfulCount(),
+		CommentIndex:    entity.CommentSearchIndex(),
+		DuplicateKeys:   entity.DuplicateKeys(),
+		// 集計から外す条件をクエリで扱えるよう、未確認の指摘があるかを保存時に求めておく。
+		AnomalyPending:       entity.HasPendingAnomalies(),
+		AnomaliesConfirmedBy: entity.AnomaliesConfirmedBy(),
+		CreatedAt:            entity.CreatedAt().Value(),
+		UpdatedAt:            entity.UpdatedAt().Value(),
 	}
 
+	for _, a := range entity.Anomalies() {
+		doc.Anomalies = append(doc.Anomalies, anomalyDocument{
+			Field:     string(a.Field),
+			Reference: string(a.Reference),
+			Value:     a.Value,
+			Median:    a.Median,
+			Lower:     a.Lower,
+			Upper:     a.Upper,
+			Score:     a.Score,
+		})
+	}
+	if confirmed := entity.AnomaliesConfirmedAt(); confirmed != nil {
+		value := confirmed.Value()
+		doc.AnomaliesConfirmedAt = &value
+	}
+	if reviewed := entity.ReviewedAt(); reviewed != nil {
+		value := reviewed.Value()
+		doc.ReviewedAt = &value
+	}
 	if branch := entity.StoreBranch(); branch != nil {
 		value := branch.Value()
 		doc.StoreBranchName = &value
@@ -385,6 +1254,50 @@ func (d *document) toEntity() (*survey_domain.Survey, error) {
 		opts = append(opts, survey_domain.WithImageURLs(urls))
 	}
 
+	// status を持たない既存ドキュメントは承認済みとして扱う。
+	if d.Status != "" {
+		status, err := survey_vo.NewStatus(d.Status)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, survey_domain.WithStatus(status))
+	}
+	if d.ReviewedAt != nil {
+		reviewedAt, err := common_vo.NewTimestamp(*d.ReviewedAt)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, survey_domain.WithReview(d.ReviewedBy, reviewedAt))
+	}
+	if d.RejectionReason != "" {
+		opts = append(opts, survey_domain.WithRejectionReason(d.RejectionReason))
+	}
+	if d.HelpfulCount > 0 {
+		opts = append(opts, survey_domain.WithHelpfulCount(d.HelpfulCount))
+	}
+	if len(d.Anomalies) > 0 {
+		anomalies := make([]survey_domain.Anomaly, 0, len(d.Anomalies))
+		for _, a := range d.Anomalies {
+			anomalies = append(anomalies, survey_domain.Anomaly{
+				Field:     survey_domain.AnomalyField(a.Field),
+				Reference: survey_domain.AnomalyReference(a.Reference),
+				Value:     a.Value,
+				Median:    a.Median,
+				Lower:     a.Lower,
+				Upper:     a.Upper,
+				Score:     a.Score,
+			})
+		}
+		opts = append(opts, survey_domain.WithAnomalies(anomalies))
+	}
+	if d.AnomaliesConfirmedAt != nil {
+		confirmedAt, err := common_vo.NewTimestamp(*d.AnomaliesConfirmedAt)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, survey_domain.WithAnomalyConfirmation(d.AnomaliesConfirmedBy, confirmedAt))
+	}
+
 	createdAt, err := common_vo.NewTimestamp(d.CreatedAt)
 	if err != nil {
 		return nil, err
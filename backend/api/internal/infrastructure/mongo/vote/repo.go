@@ -0,0 +1,134 @@
+package vote
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	vote_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/vote"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ vote_domain.Repo = (*Repo)(nil)
+
+// Repo は MongoDB バックエンドの投票リポジトリ。
+// (surveyId, ipHash) と (surveyId, deviceHash) のユニークインデックスで、どちらかが一致する重複投票を防ぐ。
+type Repo struct {
+	collection *mongo.Collection
+}
+
+// legacyVoterIndex は IP アドレスと端末トークンをまとめてハッシュ化していた頃のユニークインデックス名。
+// 新しい投票は voterHash を持たず null 同士が衝突するため、EnsureIndexes で削除する。
+const legacyVoterIndex = "surveyId_voterHash"
+
+// NewRepo は Mongo コレクションから Repo を組み立てる。
+// nil の場合は panic を発生させ、DI 段階で気付けるようにする。
+func NewRepo(col *mongo.Collection) *Repo {
+	if col == nil {
+		panic("mongo vote repo: collection is nil")
+	}
+	return &Repo{collection: col}
+}
+
+// EnsureIndexes は重複投票を防ぐユニークインデックスを作成する。
+// ハッシュは片方だけの投票もあるため、値を持つドキュメントだけを対象にした部分インデックスとする。
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	if err := r.dropLegacyIndex(ctx); err != nil {
+		return err
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "surveyId", Value: 1}, {Key: "ipHash", Value: 1}},
+			Options: options.Index().SetName("surveyId_ipHash").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"ipHash": bson.M{"$type": "string"}}),
+		},
+		{
+			Keys: bson.D{{Key: "surveyId", Value: 1}, {Key: "deviceHash", Value: 1}},
+			Options: options.Index().SetName("surveyId_deviceHash").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"deviceHash": bson.M{"$type": "string"}}),
+		},
+	})
+	return err
+}
+
+func (r *Repo) dropLegacyIndex(ctx context.Context) error {
+	specs, err := r.collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		if spec.Name == legacyVoterIndex {
+			_, err := r.collection.Indexes().DropOne(ctx, legacyVoterIndex)
+			return err
+		}
+	}
+	return nil
+}
+
+// Add は投票を保存する。IP アドレスか端末トークンのハッシュが一致する投票が既にある場合は何もせず false を返す。
+// トランザクション内で重複キーエラーを起こすとトランザクションごと中断されるため、InsertOne ではなく upsert で判定する。
+// 別の組み合わせで同時に投票されユニークインデックスに弾かれた場合も重複として false を返す。
+// その場合トランザクションは中断済みのため、呼び出し側のコミットはエラーとなり投票は反映されない。
+func (r *Repo) Add(ctx context.Context, vote *vote_domain.Vote) (bool, error) {
+	if vote == nil {
+		return false, errors.New("mongo vote repo: vote is nil")
+	}
+	surveyID, err := primitive.ObjectIDFromHex(vote.SurveyID().Value())
+	if err != nil {
+		return false, err
+	}
+
+	filter := voterFilter(surveyID, vote.Voter())
+	update := bson.M{"$setOnInsert": document{
+		SurveyID:   surveyID,
+		IPHash:     vote.Voter().IPHash().Value(),
+		DeviceHash: vote.Voter().DeviceHash().Value(),
+		CreatedAt:  vote.CreatedAt().Value(),
+	}}
+	res, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return res.UpsertedCount > 0, nil
+}
+
+// Remove は投票を取り消す。IP アドレスか端末トークンのハッシュが一致する投票を 1 件削除し、該当がない場合は false を返す。
+func (r *Repo) Remove(ctx context.Context, surveyID survey_vo.ID, voter vote_domain.Voter) (bool, error) {
+	oid, err := primitive.ObjectIDFromHex(surveyID.Value())
+	if err != nil {
+		return false, err
+	}
+	res, err := r.collection.DeleteOne(ctx, voterFilter(oid, voter))
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// voterFilter は同じアンケートに対し、投票者のいずれかのハッシュが一致する投票を探す条件を組み立てる。
+func voterFilter(surveyID primitive.ObjectID, voter vote_domain.Voter) bson.M {
+	var or bson.A
+	if h := voter.IPHash(); h != "" {
+		or = append(or, bson.M{"ipHash": h.Value()})
+	}
+	if h := voter.DeviceHash(); h != "" {
+		or = append(or, bson.M{"deviceHash": h.Value()})
+	}
+	return bson.M{"surveyId": surveyID, "$or": or}
+}
+
+// vote ドキュメント構造。_id はサーバー側で採番する。
+// ハッシュが空の場合はフィールドごと省き、部分インデックスの対象から外す。
+type document struct {
+	SurveyID   primitive.ObjectID `bson:"surveyId"`
+	IPHash     string             `bson:"ipHash,omitempty"`
+	DeviceHash string             `bson:"deviceHash,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt"`
+}
@@ -0,0 +1,127 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	admin_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/admin"
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+	admin_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/admin"
+)
+
+// ListAdmins は管理者アカウントの一覧を返す。
+func (h *handler) ListAdmins(w http.ResponseWriter, r *http.Request) {
+	admins, err := h.adminService.List(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	responses := make([]adminResponse, 0, len(admins))
+	for _, admin := range admins {
+		responses = append(responses, newAdminResponse(admin))
+	}
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// CreateAdmin は管理者アカウントを作成する。
+func (h *handler) CreateAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var payload adminCreateRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	role, err := admin_vo.NewRole(payload.Role)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin, err := h.adminService.Create(ctx, payload.Email, payload.Password, role)
+	if err != nil {
+		if errors.Is(err, admin_usecase.ErrEmailTaken) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, newAdminResponse(admin))
+}
+
+// UpdateAdminRole は管理者のロールを変更する。
+func (h *handler) UpdateAdminRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := admin_vo.NewID(chi.URLParam(r, "adminID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var payload adminRoleRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := admin_vo.NewRole(payload.Role)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin, err := h.adminService.ChangeRole(ctx, id, role)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin_usecase.ErrAdminNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, admin_usecase.ErrLastOwner):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newAdminResponse(admin))
+}
+
+// newAdminResponse は Admin 集約を HTTP レスポンスに変換する。パスワードハッシュは含めない。
+func newAdminResponse(admin *admin_domain.Admin) adminResponse {
+	resp := adminResponse{
+		ID:        admin.ID().Value(),
+		Email:     admin.Email().Value(),
+		Role:      admin.Role().Value(),
+		CreatedAt: admin.CreatedAt().Value(),
+		UpdatedAt: admin.UpdatedAt().Value(),
+	}
+	if last := admin.LastLoginAt(); last != nil {
+		value := last.Value()
+		resp.LastLoginAt = &value
+	}
+	return resp
+}
+
+type adminCreateRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+type adminRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type adminResponse struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
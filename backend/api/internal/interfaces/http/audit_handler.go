@@ -0,0 +1,152 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	audit_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/audit"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+)
+
+// auditIgnoredFields は差分に含めないフィールド。保存のたびに変わる、アンケートから集計される、
+// または一般ユーザーの投票で増減する値のため監査上の意味を持たない。
+var auditIgnoredFields = []string{"createdAt", "updatedAt", "averageRating", "surveyCount", "stats", "helpfulCount"}
+
+// ListAuditLogs は対象または操作者で監査ログを検索する。
+func (h *handler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pagination := paginationFromRequest(r)
+
+	filter, err := buildAuditFilter(r.URL.Query())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, total, err := h.auditService.List(ctx, filter, pagination)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newAuditListResponse(entries, pagination, total))
+}
+
+// recordAudit は管理操作の監査ログを記録する。
+// 本体の操作は完了しているため、記録に失敗してもリクエストは失敗させずログに残す。
+func (h *handler) recordAudit(
+	r *http.Request,
+	entityType audit_domain.EntityType,
+	entityID string,
+	action audit_domain.Action,
+	before, after map[string]interface{},
+) {
+	ctx := r.Context()
+	actor, _ := AdminSubjectFromContext(ctx)
+	requestID := middleware.GetReqID(ctx)
+
+	entry, err := audit_domain.NewEntry(
+		actor,
+		entityType,
+		entityID,
+		action,
+		audit_domain.Diff(before, after),
+		audit_domain.WithRequestID(requestID),
+	)
+	if err != nil {
+		log.Printf("failed to build audit entry request_id=%s: %v", requestID, err)
+		return
+	}
+	if err := h.auditService.Record(ctx, entry); err != nil {
+		log.Printf("failed to record audit entry request_id=%s: %v", requestID, err)
+	}
+}
+
+// auditSnapshot は HTTP レスポンス構造体を JSON 表現のマップに変換する。
+// API 利用者が見るフィールド名のまま差分を取るため、レスポンス構造体を基準にしている。
+func auditSnapshot(v interface{}) map[string]interface{} {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(buf, &snapshot); err != nil {
+		return nil
+	}
+	for _, field := range auditIgnoredFields {
+		delete(snapshot, field)
+	}
+	return snapshot
+}
+
+func buildAuditFilter(values url.Values) (audit_domain.Filter, error) {
+	var filter audit_domain.Filter
+	if v := strings.TrimSpace(values.Get("entityType")); v != "" {
+		switch audit_domain.EntityType(v) {
+		case audit_domain.EntityStore, audit_domain.EntitySurvey:
+			filter.EntityType = audit_domain.EntityType(v)
+		default:
+			return filter, errors.New("entityType must be store or survey")
+		}
+	}
+	filter.EntityID = strings.TrimSpace(values.Get("entityId"))
+	filter.Actor = strings.TrimSpace(values.Get("actor"))
+	return filter, nil
+}
+
+func newAuditListResponse(entries []*audit_domain.Entry, page common_vo.Pagination, total int64) auditListResponse {
+	items := make([]auditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]auditChangeResponse, 0, len(entry.Changes()))
+		for _, c := range entry.Changes() {
+			changes = append(changes, auditChangeResponse{Field: c.Field, Before: c.Before, After: c.After})
+		}
+		items = append(items, auditEntryResponse{
+			ID:         entry.ID(),
+			Actor:      entry.Actor(),
+			RequestID:  entry.RequestID(),
+			EntityType: string(entry.EntityType()),
+			EntityID:   entry.EntityID(),
+			Action:     string(entry.Action()),
+			Changes:    changes,
+			OccurredAt: entry.OccurredAt().Value(),
+		})
+	}
+	return auditListResponse{
+		Items: items,
+		Page:  page.Page(),
+		Limit: page.Limit(),
+		Total: total,
+	}
+}
+
+type auditChangeResponse struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type auditEntryResponse struct {
+	ID         string                `json:"id"`
+	Actor      string                `json:"actor"`
+	RequestID  string                `json:"requestId,omitempty"`
+	EntityType string                `json:"entityType"`
+	EntityID   string                `json:"entityId"`
+	Action     string                `json:"action"`
+	Changes    []auditChangeResponse `json:"changes"`
+	OccurredAt time.Time             `json:"occurredAt"`
+}
+
+type auditListResponse struct {
+	Items []auditEntryResponse `json:"items"`
+	Page  int                  `json:"page"`
+	Limit int                  `json:"limit"`
+	Total int64                `json:"total"`
+}
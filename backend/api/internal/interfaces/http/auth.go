@@ -0,0 +1,119 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+	"github.com/sngm3741/makoto-club-services/api/internal/infrastructure/auth"
+)
+
+type contextKey int
+
+const adminPrincipalKey contextKey = iota
+
+// AdminPrincipal は認証済み管理者の subject とロールを表す。
+type AdminPrincipal struct {
+	Subject string
+	Role    admin_vo.Role
+}
+
+// adminAuthMiddleware は /api/admin 配下で Bearer トークンを検証するミドルウェアを返す。
+// 検証に成功した場合は subject とロールをコンテキストに格納して次のハンドラへ渡す。
+// ロールを持たない・不正なロールのトークンは viewer として扱う。
+func adminAuthMiddleware(verifier *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw, ok := bearerToken(r)
+			if !ok {
+				respondUnauthorized(w, "missing bearer token")
+				return
+			}
+
+			claims, err := verifier.Verify(raw)
+			if err != nil {
+				if errors.Is(err, auth.ErrTokenNotAccepted) {
+					respondError(w, http.StatusForbidden, "token is not accepted by this API")
+					return
+				}
+				respondUnauthorized(w, "invalid token")
+				return
+			}
+
+			role, err := admin_vo.NewRole(claims.Role)
+			if err != nil {
+				role, _ = admin_vo.NewRole(admin_vo.RoleViewer)
+			}
+
+			log.Printf("admin request subject=%s role=%s request_id=%s %s %s",
+				claims.Subject, role.Value(), middleware.GetReqID(r.Context()), r.Method, r.URL.Path)
+
+			principal := AdminPrincipal{Subject: claims.Subject, Role: role}
+			ctx := context.WithValue(r.Context(), adminPrincipalKey, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// requirePermission はルートに必要な権限を持たないリクエストを 403 で弾くミドルウェアを返す。
+func requirePermission(permission admin_vo.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorize(w, r, permission) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authorize は認証済み管理者が permission を持つか確認し、持たない場合はエラー応答を書き込んで false を返す。
+// ハンドラ内で追加の権限確認が必要な場合もこの関数を使う。
+func authorize(w http.ResponseWriter, r *http.Request, permission admin_vo.Permission) bool {
+	principal, ok := AdminPrincipalFromContext(r.Context())
+	if !ok {
+		respondUnauthorized(w, "authentication required")
+		return false
+	}
+	if !principal.Role.Can(permission) {
+		respondError(w, http.StatusForbidden, "permission denied: "+string(permission))
+		return false
+	}
+	return true
+}
+
+// AdminPrincipalFromContext は認証済みリクエストの管理者情報を返す。
+// 認証ミドルウェアを通っていない場合は false を返す。
+func AdminPrincipalFromContext(ctx context.Context) (AdminPrincipal, bool) {
+	principal, ok := ctx.Value(adminPrincipalKey).(AdminPrincipal)
+	return principal, ok && principal.Subject != ""
+}
+
+// AdminSubjectFromContext は認証済みリクエストの subject を返す。
+// 認証ミドルウェアを通っていない場合は false を返す。
+func AdminSubjectFromContext(ctx context.Context) (string, bool) {
+	principal, ok := AdminPrincipalFromContext(ctx)
+	return principal.Subject, ok
+}
+
+// bearerToken は Authorization ヘッダーから Bearer トークンを取り出す。
+func bearerToken(r *http.Request) (string, bool) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// respondUnauthorized は WWW-Authenticate ヘッダー付きで 401 を返す。
+func respondUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="makoto-club-admin"`)
+	respondError(w, http.StatusUnauthorized, message)
+}
@@ -0,0 +1,91 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	admin_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/admin"
+	admin_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/admin"
+)
+
+// LoginAdmin はメールアドレスとパスワードで管理者を認証し、JWT を発行する。
+func (h *handler) LoginAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var payload loginRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin, pair, err := h.adminService.Login(ctx, payload.Email, payload.Password)
+	if err != nil {
+		if errors.Is(err, admin_usecase.ErrInvalidCredentials) {
+			respondUnauthorized(w, err.Error())
+			return
+		}
+		if errors.Is(err, admin_usecase.ErrTokenIssuerUnavailable) {
+			respondError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newTokenResponse(admin, pair))
+}
+
+// RefreshAdminToken はリフレッシュトークンから新しいトークンの組を発行する。
+func (h *handler) RefreshAdminToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var payload refreshRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	admin, pair, err := h.adminService.Refresh(ctx, payload.RefreshToken)
+	if err != nil {
+		if errors.Is(err, admin_usecase.ErrInvalidCredentials) {
+			respondUnauthorized(w, err.Error())
+			return
+		}
+		if errors.Is(err, admin_usecase.ErrTokenIssuerUnavailable) {
+			respondError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newTokenResponse(admin, pair))
+}
+
+func newTokenResponse(admin *admin_domain.Admin, pair admin_usecase.TokenPair) tokenResponse {
+	return tokenResponse{
+		TokenType:        "Bearer",
+		AccessToken:      pair.AccessToken,
+		ExpiresAt:        pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+		Admin:            newAdminResponse(admin),
+	}
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type tokenResponse struct {
+	TokenType        string        `json:"tokenType"`
+	AccessToken      string        `json:"accessToken"`
+	ExpiresAt        time.Time     `json:"expiresAt"`
+	RefreshToken     string        `json:"refreshToken"`
+	RefreshExpiresAt time.Time     `json:"refreshExpiresAt"`
+	Admin            adminResponse `json:"admin"`
+}
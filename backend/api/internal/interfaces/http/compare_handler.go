@@ -0,0 +1,88 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	statistics_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/statistics"
+)
+
+// CompareStores は ids=a,b,c で指定した店舗を並べ、集計値と比較対象の中での指標ごとの順位を返す。
+// 店舗は 2〜5 件で指定し、結果は指定した順に並ぶ。
+func (h *handler) CompareStores(w http.ResponseWriter, r *http.Request) {
+	ids, err := parseStoreIDList(r.URL.Query().Get("ids"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, err := h.statisticsService.Compare(r.Context(), ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, statistics_usecase.ErrStoreNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, statistics_usecase.ErrInvalidCompareStores):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	resp := storeComparisonResponse{Stores: make([]storeComparisonEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		resp.Stores = append(resp.Stores, newStoreComparisonEntryResponse(entry))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// parseStoreIDList はカンマ区切りの店舗 ID を VO に変換する。空の要素は無視する。
+func parseStoreIDList(raw string) ([]store_vo.ID, error) {
+	var ids []store_vo.ID
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := parseStoreID(part)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+type storeComparisonResponse struct {
+	Stores []storeComparisonEntryResponse `json:"stores"`
+}
+
+type storeComparisonEntryResponse struct {
+	Store       storeResponse                 `json:"store"`
+	Stats       storeStatsResponse            `json:"stats"`
+	LatestVisit *string                       `json:"latestVisit,omitempty"`
+	Ranks       map[string]metricRankResponse `json:"ranks"`
+}
+
+type metricRankResponse struct {
+	Rank  int     `json:"rank"`
+	Score float64 `json:"score"`
+}
+
+func newStoreComparisonEntryResponse(entry statistics_usecase.ComparisonEntry) storeComparisonEntryResponse {
+	resp := storeComparisonEntryResponse{
+		Store: newStoreResponse(entry.Store),
+		Stats: newStoreStatsResponse(entry.Stats),
+		Ranks: make(map[string]metricRankResponse, len(entry.Ranks)),
+	}
+	if entry.LatestVisit != nil {
+		value := entry.LatestVisit.Format("2006-01")
+		resp.LatestVisit = &value
+	}
+	for metric, rank := range entry.Ranks {
+		resp.Ranks[string(metric)] = metricRankResponse{Rank: rank.Rank, Score: rank.Score}
+	}
+	return resp
+}
@@ -0,0 +1,152 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	dashboard_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/dashboard"
+	dashboard_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/dashboard"
+)
+
+// GetDashboard は管理画面のダッシュボードに表示する運用指標を返す。
+// granularity=day|week（省略時 day）と periods で推移の期間、staleMonths で更新が止まっている店舗の基準、
+// limit で店舗・都道府県の一覧の件数を指定する。
+func (h *handler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	granularity, err := dashboard_usecase.ParseGranularity(query.Get("granularity"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dashboard, err := h.dashboardService.Get(r.Context(), dashboard_usecase.Query{
+		Granularity: granularity,
+		Periods:     parseQueryInt(query.Get("periods")),
+		StaleMonths: parseQueryInt(query.Get("staleMonths")),
+		Limit:       parseQueryInt(query.Get("limit")),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newDashboardResponse(dashboard))
+}
+
+type dashboardResponse struct {
+	Granularity          string                       `json:"granularity"`
+	Timezone             string                       `json:"timezone"`
+	Since                time.Time                    `json:"since"`
+	Submissions          dashboardSeriesResponse      `json:"submissions"`
+	PublishedSurveys     dashboardSeriesResponse      `json:"publishedSurveys"`
+	NewStores            dashboardSeriesResponse      `json:"newStores"`
+	Pending              dashboardPendingResponse     `json:"pending"`
+	StoresWithoutSurveys dashboardStoreListResponse   `json:"storesWithoutSurveys"`
+	StaleStores          dashboardStaleStoresResponse `json:"staleStores"`
+	TopPrefectures       []prefectureActivityResponse `json:"topPrefectures"`
+}
+
+type dashboardSeriesResponse struct {
+	Total  int64                    `json:"total"`
+	Points []dashboardPointResponse `json:"points"`
+}
+
+type dashboardPointResponse struct {
+	Start time.Time `json:"start"`
+	Count int64     `json:"count"`
+}
+
+type dashboardPendingResponse struct {
+	Submissions int64 `json:"submissions"`
+	Surveys     int64 `json:"surveys"`
+}
+
+type dashboardStoreListResponse struct {
+	Total int64                   `json:"total"`
+	Items []storeActivityResponse `json:"items"`
+}
+
+type dashboardStaleStoresResponse struct {
+	Months int       `json:"months"`
+	Before time.Time `json:"before"`
+	dashboardStoreListResponse
+}
+
+type storeActivityResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	BranchName     *string    `json:"branchName,omitempty"`
+	Prefecture     string     `json:"prefecture"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	SurveyCount    int        `json:"surveyCount"`
+	LastSurveyedAt *time.Time `json:"lastSurveyedAt,omitempty"`
+}
+
+type prefectureActivityResponse struct {
+	Prefecture  string `json:"prefecture"`
+	Total       int64  `json:"total"`
+	Surveys     int64  `json:"surveys"`
+	Submissions int64  `json:"submissions"`
+	NewStores   int64  `json:"newStores"`
+}
+
+func newDashboardResponse(d dashboard_usecase.Dashboard) dashboardResponse {
+	resp := dashboardResponse{
+		Granularity:      string(d.Granularity),
+		Timezone:         d.Timezone,
+		Since:            d.Since,
+		Submissions:      newDashboardSeriesResponse(d.Submissions),
+		PublishedSurveys: newDashboardSeriesResponse(d.PublishedSurveys),
+		NewStores:        newDashboardSeriesResponse(d.NewStores),
+		Pending: dashboardPendingResponse{
+			Submissions: d.Pending.Submissions,
+			Surveys:     d.Pending.Surveys,
+		},
+		StoresWithoutSurveys: newDashboardStoreListResponse(d.StoresWithoutSurveys),
+		StaleStores: dashboardStaleStoresResponse{
+			Months:                     d.StaleMonths,
+			Before:                     d.StaleBefore,
+			dashboardStoreListResponse: newDashboardStoreListResponse(d.StaleStores),
+		},
+		TopPrefectures: make([]prefectureActivityResponse, 0, len(d.TopPrefectures)),
+	}
+	for _, p := range d.TopPrefectures {
+		resp.TopPrefectures = append(resp.TopPrefectures, prefectureActivityResponse{
+			Prefecture:  p.Prefecture,
+			Total:       p.Total(),
+			Surveys:     p.Surveys,
+			Submissions: p.Submissions,
+			NewStores:   p.NewStores,
+		})
+	}
+	return resp
+}
+
+func newDashboardSeriesResponse(points []dashboard_domain.CountPoint) dashboardSeriesResponse {
+	resp := dashboardSeriesResponse{Points: make([]dashboardPointResponse, 0, len(points))}
+	for _, p := range points {
+		resp.Total += p.Count
+		resp.Points = append(resp.Points, dashboardPointResponse{Start: p.Start, Count: p.Count})
+	}
+	return resp
+}
+
+func newDashboardStoreListResponse(list dashboard_usecase.StoreList) dashboardStoreListResponse {
+	resp := dashboardStoreListResponse{Total: list.Total, Items: make([]storeActivityResponse, 0, len(list.Items))}
+	for _, s := range list.Items {
+		item := storeActivityResponse{
+			ID:             s.StoreID.Value(),
+			Name:           s.Name.Value(),
+			Prefecture:     s.Prefecture.Value(),
+			CreatedAt:      s.CreatedAt,
+			SurveyCount:    s.SurveyCount,
+			LastSurveyedAt: s.LastSurveyedAt,
+		}
+		if s.BranchName != nil {
+			value := s.BranchName.Value()
+			item.BranchName = &value
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
@@ -0,0 +1,87 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	survey_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/survey"
+)
+
+// surveyCreateOptionsFromRequest は duplicates クエリからアンケート登録の指定を組み立てる。
+func surveyCreateOptionsFromRequest(r *http.Request) (survey_usecase.CreateOptions, error) {
+	var opts survey_usecase.CreateOptions
+	if raw := strings.TrimSpace(r.URL.Query().Get("duplicates")); raw != "" {
+		policy, err := survey_usecase.ParseDuplicatePolicy(raw)
+		if err != nil {
+			return survey_usecase.CreateOptions{}, err
+		}
+		opts.DuplicatePolicy = policy
+	}
+	return opts, nil
+}
+
+// respondDuplicateError は重複により登録を拒否した場合に、疑いのあるアンケートを添えて 409 を返す。
+// err が重複によるものでない場合は何もせず false を返す。
+func respondDuplicateError(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, survey_usecase.ErrDuplicateSurvey) {
+		return false
+	}
+	resp := duplicateErrorResponse{Error: err.Error()}
+	var dupErr *survey_usecase.DuplicateError
+	if errors.As(err, &dupErr) {
+		resp.Duplicates = newSurveyDuplicateResponses(dupErr.Candidates)
+	}
+	respondJSON(w, http.StatusConflict, resp)
+	return true
+}
+
+type duplicateErrorResponse struct {
+	Error      string                    `json:"error"`
+	Duplicates []surveyDuplicateResponse `json:"duplicates"`
+}
+
+// adminSurveyDetailResponse は管理画面向けのアンケート詳細。重複の疑いがあるアンケートを添える。
+type adminSurveyDetailResponse struct {
+	adminSurveyResponse
+	Duplicates []surveyDuplicateResponse `json:"duplicates"`
+}
+
+type surveyDuplicateResponse struct {
+	ID             string    `json:"id"`
+	StoreID        string    `json:"storeId"`
+	StoreName      string    `json:"storeName"`
+	StoreBranch    *string   `json:"storeBranch,omitempty"`
+	VisitedPeriod  string    `json:"visitedPeriod"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Reasons        []string  `json:"reasons"`
+	TextSimilarity float64   `json:"textSimilarity"`
+}
+
+func newSurveyDuplicateResponses(candidates []survey_domain.DuplicateCandidate) []surveyDuplicateResponse {
+	resp := make([]surveyDuplicateResponse, 0, len(candidates))
+	for _, c := range candidates {
+		item := surveyDuplicateResponse{
+			ID:             c.Survey.ID().Value(),
+			StoreID:        c.Survey.StoreID().Value(),
+			StoreName:      c.Survey.StoreName().Value(),
+			VisitedPeriod:  c.Survey.VisitedPeriod().Value().Format("2006-01"),
+			Status:         c.Survey.Status().Value(),
+			CreatedAt:      c.Survey.CreatedAt().Value(),
+			Reasons:        make([]string, 0, len(c.Reasons)),
+			TextSimilarity: c.TextSimilarity,
+		}
+		if branch := c.Survey.StoreBranch(); branch != nil {
+			value := branch.Value()
+			item.StoreBranch = &value
+		}
+		for _, reason := range c.Reasons {
+			item.Reasons = append(item.Reasons, string(reason))
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
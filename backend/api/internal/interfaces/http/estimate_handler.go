@@ -0,0 +1,111 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	estimate_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/estimate"
+)
+
+// EstimateEarnings は年齢・スペックなどの属性から、1 日あたりの平均稼ぎ(万円)の目安を返す。
+// age/specScore は必須で、workType/industry/prefecture/storeId を指定すると比較対象を絞り込む。
+// 比較できるアンケートが足りない場合は 422 を返す。
+func (h *handler) EstimateEarnings(w http.ResponseWriter, r *http.Request) {
+	var payload estimateRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	profile, err := payload.toProfile()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	estimate, err := h.estimateService.Estimate(r.Context(), profile)
+	if err != nil {
+		switch {
+		case errors.Is(err, estimate_usecase.ErrStoreNotFound):
+			respondError(w, http.StatusNotFound, "store not found")
+		case errors.Is(err, estimate_usecase.ErrInsufficientData):
+			respondError(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, estimateResponse{
+		Low:               estimate.Low,
+		Expected:          estimate.Expected,
+		High:              estimate.High,
+		Scope:             string(estimate.Scope),
+		ComparableSurveys: estimate.ComparableSurveys,
+		ScopeSurveys:      estimate.ScopeSurveys,
+		ModelUpdatedAt:    estimate.ModelUpdatedAt,
+	})
+}
+
+type estimateRequest struct {
+	Age        int    `json:"age"`
+	SpecScore  int    `json:"specScore"`
+	WorkType   string `json:"workType,omitempty"`
+	Industry   string `json:"industry,omitempty"`
+	Prefecture string `json:"prefecture,omitempty"`
+	StoreID    string `json:"storeId,omitempty"`
+}
+
+func (p estimateRequest) toProfile() (estimate_usecase.Profile, error) {
+	var (
+		profile estimate_usecase.Profile
+		err     error
+	)
+	if profile.Age, err = survey_vo.NewAge(p.Age); err != nil {
+		return profile, err
+	}
+	if profile.SpecScore, err = survey_vo.NewSpecScore(p.SpecScore); err != nil {
+		return profile, err
+	}
+	if v := strings.TrimSpace(p.WorkType); v != "" {
+		workType, err := survey_vo.NewWorkType(v)
+		if err != nil {
+			return profile, err
+		}
+		profile.WorkType = &workType
+	}
+	if v := strings.TrimSpace(p.Industry); v != "" {
+		industry, err := store_vo.NewIndustry(v)
+		if err != nil {
+			return profile, err
+		}
+		profile.Industry = &industry
+	}
+	if v := strings.TrimSpace(p.Prefecture); v != "" {
+		prefecture, err := store_vo.NewPrefecture(v)
+		if err != nil {
+			return profile, err
+		}
+		profile.Prefecture = &prefecture
+	}
+	if v := strings.TrimSpace(p.StoreID); v != "" {
+		id, err := parseStoreID(v)
+		if err != nil {
+			return profile, err
+		}
+		profile.StoreID = &id
+	}
+	return profile, nil
+}
+
+type estimateResponse struct {
+	Low               float64   `json:"low"`
+	Expected          float64   `json:"expected"`
+	High              float64   `json:"high"`
+	Scope             string    `json:"scope"`
+	ComparableSurveys int       `json:"comparableSurveys"`
+	ScopeSurveys      int       `json:"scopeSurveys"`
+	ModelUpdatedAt    time.Time `json:"modelUpdatedAt"`
+}
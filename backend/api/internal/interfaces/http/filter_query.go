@@ -0,0 +1,129 @@
+package interfaces
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+)
+
+// queryList はカンマ区切り、または同じキーを繰り返して指定された値を並べて返す。空の値は除く。
+// 例: prefecture=東京都,神奈川県 と prefecture=東京都&prefecture=神奈川県 は同じ指定になる。
+func queryList(values url.Values, key string) []string {
+	var list []string
+	for _, raw := range values[key] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				list = append(list, v)
+			}
+		}
+	}
+	return list
+}
+
+// parseQueryList は queryList の各値を parse で VO に変換する。1 つでも不正な値があればエラーを返す。
+func parseQueryList[T any](values url.Values, key string, parse func(string) (T, error)) ([]T, error) {
+	raw := queryList(values, key)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	items := make([]T, 0, len(raw))
+	for _, v := range raw {
+		item, err := parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseQueryRange は <key>Min / <key>Max の 2 つのクエリから範囲を組み立てる。どちらも省略できる。
+// 各端の値は parse で VO を通して検証し、VO が丸めた値を範囲の端とする。
+func parseQueryRange(values url.Values, key string, parse func(string) (float64, error)) (common_vo.Range, error) {
+	bound := func(name string) (*float64, error) {
+		raw := strings.TrimSpace(values.Get(name))
+		if raw == "" {
+			return nil, nil
+		}
+		v, err := parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return &v, nil
+	}
+	min, err := bound(key + "Min")
+	if err != nil {
+		return common_vo.Range{}, err
+	}
+	max, err := bound(key + "Max")
+	if err != nil {
+		return common_vo.Range{}, err
+	}
+	r, err := common_vo.NewRange(min, max)
+	if err != nil {
+		return common_vo.Range{}, fmt.Errorf("%s: %w", key, err)
+	}
+	return r, nil
+}
+
+// parseQueryBool は true/false のクエリを返す。省略した場合は nil。
+func parseQueryBool(values url.Values, key string) (*bool, error) {
+	raw := strings.TrimSpace(values.Get(key))
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s は true / false のいずれかを指定してください", key)
+	}
+	return &v, nil
+}
+
+// intBound は整数の VO を通して範囲の端を検証する parse 関数を作る。
+func intBound[T interface{ Value() int }](newVO func(int) (T, error)) func(string) (float64, error) {
+	return func(raw string) (float64, error) {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, fmt.Errorf("整数を指定してください: %q", raw)
+		}
+		vo, err := newVO(n)
+		if err != nil {
+			return 0, err
+		}
+		return float64(vo.Value()), nil
+	}
+}
+
+// floatBound は小数の VO を通して範囲の端を検証する parse 関数を作る。
+func floatBound[T interface{ Value() float64 }](newVO func(float64) (T, error)) func(string) (float64, error) {
+	return func(raw string) (float64, error) {
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, fmt.Errorf("数値を指定してください: %q", raw)
+		}
+		vo, err := newVO(f)
+		if err != nil {
+			return 0, err
+		}
+		return vo.Value(), nil
+	}
+}
+
+// floatRangeBound は min 以上 max 以下の小数として範囲の端を検証する parse 関数を作る。
+// 店舗統計の平均値のように小数を取る項目で使い、範囲外の値は丸めずにエラーとする。
+func floatRangeBound(min, max float64) func(string) (float64, error) {
+	return func(raw string) (float64, error) {
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, fmt.Errorf("数値を指定してください: %q", raw)
+		}
+		if f < min || f > max {
+			return 0, fmt.Errorf("%g〜%g の範囲で指定してください: %q", min, max, raw)
+		}
+		return f, nil
+	}
+}
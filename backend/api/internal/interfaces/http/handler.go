@@ -18,13 +18,21 @@ import (
 	"github.com/go-chi/chi/v5"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	audit_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/audit"
 	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
 	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
 	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
 	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	admin_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/admin"
+	audit_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/audit"
+	dashboard_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/dashboard"
+	estimate_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/estimate"
+	statistics_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/statistics"
 	store_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/store"
+	submission_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/submission"
 	survey_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/survey"
+	vote_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/vote"
 )
 
 var (
@@ -35,10 +43,20 @@ var (
 	httpClient                  = &http.Client{Timeout: 5 * time.Second}
 )
 
-// handler は Store/Suvey ユースケースを束ねて HTTP I/O を扱う。
+// messengerMatchLimit は通知に載せる候補店舗の件数。
+const messengerMatchLimit = 3
+
+// handler は Store/Suvey/Submission/Admin/Audit/Statistics/Vote/Dashboard/Estimate ユースケースを束ねて HTTP I/O を扱う。
 type handler struct {
-	storeService  store_usecase.Service
-	surveyService survey_usecase.Service
+	storeService      store_usecase.Service
+	surveyService     survey_usecase.Service
+	submissionService submission_usecase.Service
+	adminService      admin_usecase.Service
+	auditService      audit_usecase.Service
+	statisticsService statistics_usecase.Service
+	voteService       vote_usecase.Service
+	dashboardService  dashboard_usecase.Service
+	estimateService   estimate_usecase.Service
 }
 
 // Handler は HTTP 層で外部公開されるハンドラ群を定義する。
@@ -54,7 +72,15 @@ type Handler interface {
 	GetStoreByID(w http.ResponseWriter, r *http.Request)
 	GetSurveyByID(w http.ResponseWriter, r *http.Request)
 	GetSurveysByStoreID(w http.ResponseWriter, r *http.Request)
+	GetStoreStats(w http.ResponseWriter, r *http.Request)
+	GetRankings(w http.ResponseWriter, r *http.Request)
+	GetStoreTrends(w http.ResponseWriter, r *http.Request)
+	CompareStores(w http.ResponseWriter, r *http.Request)
+	GetTrends(w http.ResponseWriter, r *http.Request)
+	EstimateEarnings(w http.ResponseWriter, r *http.Request)
 	GetAdminSurveyByID(w http.ResponseWriter, r *http.Request)
+	MarkSurveyHelpful(w http.ResponseWriter, r *http.Request)
+	UnmarkSurveyHelpful(w http.ResponseWriter, r *http.Request)
 
 	ListStores(w http.ResponseWriter, r *http.Request)
 	ListAdminStores(w http.ResponseWriter, r *http.Request)
@@ -63,18 +89,85 @@ type Handler interface {
 
 	UpdateStore(w http.ResponseWriter, r *http.Request)
 	UpdateSurvey(w http.ResponseWriter, r *http.Request)
+
+	ListTrash(w http.ResponseWriter, r *http.Request)
+	RestoreStore(w http.ResponseWriter, r *http.Request)
+	RestoreSurvey(w http.ResponseWriter, r *http.Request)
+	PurgeStore(w http.ResponseWriter, r *http.Request)
+	PurgeSurvey(w http.ResponseWriter, r *http.Request)
+	ApproveSurvey(w http.ResponseWriter, r *http.Request)
+	RejectSurvey(w http.ResponseWriter, r *http.Request)
+	ConfirmSurveyAnomalies(w http.ResponseWriter, r *http.Request)
+
+	ListSubmissions(w http.ResponseWriter, r *http.Request)
+	MatchStores(w http.ResponseWriter, r *http.Request)
+	RecalculateStoreStats(w http.ResponseWriter, r *http.Request)
+	MatchSubmissionStores(w http.ResponseWriter, r *http.Request)
+	GetSubmissionByID(w http.ResponseWriter, r *http.Request)
+	ConvertSubmission(w http.ResponseWriter, r *http.Request)
+	RejectSubmission(w http.ResponseWriter, r *http.Request)
+
+	LoginAdmin(w http.ResponseWriter, r *http.Request)
+	RefreshAdminToken(w http.ResponseWriter, r *http.Request)
+	ListAdmins(w http.ResponseWriter, r *http.Request)
+	CreateAdmin(w http.ResponseWriter, r *http.Request)
+	UpdateAdminRole(w http.ResponseWriter, r *http.Request)
+
+	ListAuditLogs(w http.ResponseWriter, r *http.Request)
+	GetDashboard(w http.ResponseWriter, r *http.Request)
 }
 
 // NewHandler はユースケースを受け取り、HTTP ハンドラ実装を返す。
 // nil が渡された場合は panic し、DI ミスを早期に検知する。
-func NewHandler(storeService store_usecase.Service, surveyService survey_usecase.Service) Handler {
+func NewHandler(
+	storeService store_usecase.Service,
+	surveyService survey_usecase.Service,
+	submissionService submission_usecase.Service,
+	adminService admin_usecase.Service,
+	auditService audit_usecase.Service,
+	statisticsService statistics_usecase.Service,
+	voteService vote_usecase.Service,
+	dashboardService dashboard_usecase.Service,
+	estimateService estimate_usecase.Service,
+) Handler {
 	if storeService == nil {
 		panic("http handler: store service is nil")
 	}
 	if surveyService == nil {
 		panic("http handler: survey service is nil")
 	}
-	return &handler{storeService: storeService, surveyService: surveyService}
+	if submissionService == nil {
+		panic("http handler: submission service is nil")
+	}
+	if adminService == nil {
+		panic("http handler: admin service is nil")
+	}
+	if auditService == nil {
+		panic("http handler: audit service is nil")
+	}
+	if statisticsService == nil {
+		panic("http handler: statistics service is nil")
+	}
+	if voteService == nil {
+		panic("http handler: vote service is nil")
+	}
+	if dashboardService == nil {
+		panic("http handler: dashboard service is nil")
+	}
+	if estimateService == nil {
+		panic("http handler: estimate service is nil")
+	}
+	return &handler{
+		storeService:      storeService,
+		surveyService:     surveyService,
+		submissionService: submissionService,
+		adminService:      adminService,
+		auditService:      auditService,
+		statisticsService: statisticsService,
+		voteService:       voteService,
+		dashboardService:  dashboardService,
+		estimateService:   estimateService,
+	}
 }
 
 // GetSurveysByStoreID は /stores/{storeID}/surveys の一覧を返す。
@@ -111,54 +204,48 @@ func (h *handler) GetSurveysByStoreID(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, responses)
 }
 
-// ListSurveys は /surveys の汎用一覧 API。 storeId または prefecture でフィルタする。
+// ListSurveys は /surveys の汎用一覧 API。承認済みのアンケートを buildSurveyAdminFilter の条件で絞り込む。
 func (h *handler) ListSurveys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	query := r.URL.Query()
-	pagination := paginationFromRequest(r)
-	sortKey, err := sortKeyFromQuery(query.Get("sort"))
+	pagination, err := cursorPaginationFromRequest(r)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
-	storeIDParam := query.Get("storeId")
-	var (
-		surveys []*survey_domain.Survey
-		total   int64
-	)
-
-	filter, err := buildSurveyAdminFilter(query)
+	sortKey, err := sortKeyForPagination(query.Get("sort"), pagination)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	switch {
-	case storeIDParam != "":
-		storeID, err := store_vo.NewID(storeIDParam)
-		if err != nil {
-			respondError(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		surveys, total, err = h.surveyService.GetByStore(ctx, storeID, sortKey, pagination)
-	default:
-		// 公開一覧も AdminFilter を使って prefecture / industry / keyword で検索できるようにする。
-		surveys, total, err = h.surveyService.ListAdmin(ctx, filter, sortKey, pagination)
+	filter, err := buildSurveyAdminFilter(query)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
+	// 公開一覧では承認済みのアンケートのみ返し、管理画面向けの外れ値の絞り込みは受け付けない。
+	filter.Status = nil
+	filter.PublishedOnly = true
+	filter.PendingAnomalies = false
 
+	surveys, info, err := h.surveyService.ListAdmin(ctx, filter, sortKey, pagination)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondListError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newSurveyListResponse(surveys, pagination, total))
+	respondJSON(w, http.StatusOK, newSurveyPageResponse(surveys, pagination, info, filter.Text))
 }
 
 // ListAdminSurveys は管理用に全アンケートを取得する。
 func (h *handler) ListAdminSurveys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	pagination := paginationFromRequest(r)
+	pagination, err := cursorPaginationFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	sortKey := defaultSortKey()
 
 	filter, err := buildSurveyAdminFilter(r.URL.Query())
@@ -167,16 +254,16 @@ func (h *handler) ListAdminSurveys(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	surveys, total, err := h.surveyService.ListAdmin(ctx, filter, sortKey, pagination)
+	surveys, info, err := h.surveyService.ListAdmin(ctx, filter, sortKey, pagination)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondListError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newSurveyListResponse(surveys, pagination, total))
+	respondJSON(w, http.StatusOK, newAdminSurveyPageResponse(surveys, pagination, info, filter.Text))
 }
 
-// GetAdminSurveyByID は管理者向けに単一アンケートを取得する。
+// GetAdminSurveyByID は管理者向けに単一アンケートを取得する。重複の疑いがあるアンケートも併せて返す。
 func (h *handler) GetAdminSurveyByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
@@ -195,10 +282,19 @@ func (h *handler) GetAdminSurveyByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newSurveyResponse(survey))
+	duplicates, err := h.surveyService.FindDuplicates(ctx, survey)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, adminSurveyDetailResponse{
+		adminSurveyResponse: newAdminSurveyResponse(survey),
+		Duplicates:          newSurveyDuplicateResponses(duplicates),
+	})
 }
 
-// GetSurveyByID はアンケート ID で 1 件取得する。
+// GetSurveyByID はアンケート ID で 1 件取得する。承認済みでないものは 404 とする。
 func (h *handler) GetSurveyByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
@@ -207,7 +303,7 @@ func (h *handler) GetSurveyByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	survey, err := h.surveyService.FindByID(ctx, id)
+	survey, err := h.surveyService.FindPublishedByID(ctx, id)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -220,23 +316,46 @@ func (h *handler) GetSurveyByID(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, newSurveyResponse(survey))
 }
 
-// SubmitSurvey は一般ユーザーの投稿を受け取り、DB には保存せず Discord 通知だけ行う。
+// SubmitSurvey は一般ユーザーの投稿を submissions に保存し、Discord へ通知する。
+// 通知に失敗しても投稿は失われないよう、保存は同期的に行い失敗時は 500 を返す。
 func (h *handler) SubmitSurvey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	var payload surveyRequest
 	if err := decodeJSON(r, &payload); err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// HTTPリクエストのキャンセルに引きずられないよう、バックグラウンドで通知を送る。
-	go sendSurveyToMessenger(context.Background(), payload)
-	respondJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+	submission, err := h.submissionService.Submit(ctx, newSubmissionPayload(payload), clientIP(r))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// HTTPリクエストのキャンセルに引きずられないよう、候補店舗の照合と通知はバックグラウンドで行う。
+	go func() {
+		matchCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		matches, err := h.storeService.Match(matchCtx, newMatchQuery(submission.Payload()), messengerMatchLimit)
+		if err != nil {
+			log.Printf("failed to match stores for submission %s: %v", submission.ID().Value(), err)
+		}
+		sendSurveyToMessenger(context.Background(), submission.ID().Value(), payload, matches)
+	}()
+	respondJSON(w, http.StatusAccepted, map[string]string{"status": "accepted", "id": submission.ID().Value()})
 }
 
 // CreateSurvey は管理者が店舗ID付きで登録する経路。
 // 店舗メタデータは storeID から取得し、Survey 集約へコピーする。
+// duplicates=allow|block で重複の疑いがある場合の扱いを上書きでき、拒否した場合は 409 を返す。
 func (h *handler) CreateSurvey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	opts, err := surveyCreateOptionsFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	var payload surveyRequest
 	if err := decodeJSON(r, &payload); err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
@@ -255,12 +374,17 @@ func (h *handler) CreateSurvey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.surveyService.Create(ctx, entity); err != nil {
+	if err := h.surveyService.CreateWithOptions(ctx, entity, opts); err != nil {
+		if respondDuplicateError(w, err) {
+			return
+		}
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, newSurveyResponse(entity))
+	response := newAdminSurveyResponse(entity)
+	h.recordAudit(r, audit_domain.EntitySurvey, entity.ID().Value(), audit_domain.ActionCreate, nil, auditSnapshot(response))
+	respondJSON(w, http.StatusCreated, response)
 }
 
 // UpdateSurvey は既存アンケートを上書き保存する。
@@ -278,7 +402,30 @@ func (h *handler) UpdateSurvey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entity, err := h.buildSurveyEntity(ctx, id, payload)
+	before, err := h.surveyService.FindByID(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// ゴミ箱にあるアンケートを上書きすると削除日時が消えて復元扱いになるため、先に復元させる。
+	if before == nil {
+		trashed, err := h.surveyService.FindByIDIncludingDeleted(ctx, id)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if trashed != nil {
+			respondError(w, http.StatusConflict, "survey is in trash; restore it before updating")
+			return
+		}
+	}
+
+	// 内容の編集で掲載ステータスや投票数が変わらないよう、審査結果と「参考になった」の件数は既存のものを引き継ぐ。
+	var opts []survey_domain.Option
+	if before != nil {
+		opts = moderationOptions(before)
+	}
+	entity, err := h.buildSurveyEntity(ctx, id, payload, opts...)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -289,10 +436,16 @@ func (h *handler) UpdateSurvey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newSurveyResponse(entity))
+	response := newAdminSurveyResponse(entity)
+	var beforeSnapshot map[string]interface{}
+	if before != nil {
+		beforeSnapshot = auditSnapshot(newAdminSurveyResponse(before))
+	}
+	h.recordAudit(r, audit_domain.EntitySurvey, id.Value(), audit_domain.ActionUpdate, beforeSnapshot, auditSnapshot(response))
+	respondJSON(w, http.StatusOK, response)
 }
 
-// DeleteSurvey はアンケートを物理削除する。
+// DeleteSurvey はアンケートを論理削除する。復元・完全削除はゴミ箱 API から行う。
 func (h *handler) DeleteSurvey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
@@ -301,14 +454,97 @@ func (h *handler) DeleteSurvey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.surveyService.Delete(ctx, id); err != nil {
+	before, err := h.surveyService.FindByID(ctx, id)
+	if err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if before == nil {
+		respondError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+	beforeSnapshot := auditSnapshot(newAdminSurveyResponse(before))
+
+	deleted, err := h.surveyService.Delete(ctx, id)
+	if err != nil {
+		respondTrashError(w, err)
+		return
+	}
+
+	h.recordAudit(r, audit_domain.EntitySurvey, id.Value(), audit_domain.ActionDelete, beforeSnapshot, auditSnapshot(newAdminSurveyResponse(deleted)))
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ApproveSurvey はアンケートを承認し、公開 API に表示されるようにする。
+func (h *handler) ApproveSurvey(w http.ResponseWriter, r *http.Request) {
+	h.moderateSurvey(w, r, func(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error) {
+		return h.surveyService.Approve(ctx, id, reviewer)
+	})
+}
+
+// RejectSurvey はアンケートを非承認とし、公開 API から外す。
+func (h *handler) RejectSurvey(w http.ResponseWriter, r *http.Request) {
+	var payload surveyRejectRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.moderateSurvey(w, r, func(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error) {
+		return h.surveyService.Reject(ctx, id, reviewer, payload.Reason)
+	})
+}
+
+// ConfirmSurveyAnomalies は外れ値の指摘を確認し、アンケートを統計・ランキングの集計に含める。
+func (h *handler) ConfirmSurveyAnomalies(w http.ResponseWriter, r *http.Request) {
+	h.moderateSurvey(w, r, func(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error) {
+		return h.surveyService.ConfirmAnomalies(ctx, id, reviewer)
+	})
+}
+
+// moderateSurvey は承認・非承認・外れ値の確認の共通処理。審査前後の差分を監査ログに残す。
+func (h *handler) moderateSurvey(
+	w http.ResponseWriter,
+	r *http.Request,
+	moderate func(context.Context, survey_vo.ID, string) (*survey_domain.Survey, error),
+) {
+	ctx := r.Context()
+	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before, err := h.surveyService.FindByID(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if before == nil {
+		respondError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+	beforeSnapshot := auditSnapshot(newAdminSurveyResponse(before))
+
+	reviewer, _ := AdminSubjectFromContext(ctx)
+	survey, err := moderate(ctx, id, reviewer)
+	if err != nil {
+		switch {
+		case errors.Is(err, survey_usecase.ErrSurveyNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, survey_domain.ErrNoAnomalies):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response := newAdminSurveyResponse(survey)
+	h.recordAudit(r, audit_domain.EntitySurvey, id.Value(), audit_domain.ActionUpdate, beforeSnapshot, auditSnapshot(response))
+	respondJSON(w, http.StatusOK, response)
+}
+
 // CreateStore は店舗を新規作成する。Mongo 互換の ObjectID を自前で生成する。
 func (h *handler) CreateStore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -335,7 +571,9 @@ func (h *handler) CreateStore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, newStoreResponse(entity))
+	response := newStoreResponse(entity)
+	h.recordAudit(r, audit_domain.EntityStore, entity.ID().Value(), audit_domain.ActionCreate, nil, auditSnapshot(response))
+	respondJSON(w, http.StatusCreated, response)
 }
 
 // UpdateStore は既存店舗の情報を上書きする。
@@ -359,15 +597,41 @@ func (h *handler) UpdateStore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	before, err := h.storeService.FindByID(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// ゴミ箱にある店舗を上書きすると削除日時が消えて復元扱いになるため、先に復元させる。
+	if before == nil {
+		trashed, err := h.storeService.FindByIDIncludingDeleted(ctx, id)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if trashed != nil {
+			respondError(w, http.StatusConflict, "store is in trash; restore it before updating")
+			return
+		}
+	}
+
 	if err := h.storeService.Save(ctx, entity); err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newStoreResponse(entity))
+	response := newStoreResponse(entity)
+	var beforeSnapshot map[string]interface{}
+	if before != nil {
+		beforeSnapshot = auditSnapshot(newStoreResponse(before))
+	}
+	h.recordAudit(r, audit_domain.EntityStore, id.Value(), audit_domain.ActionUpdate, beforeSnapshot, auditSnapshot(response))
+	respondJSON(w, http.StatusOK, response)
 }
 
-// DeleteStore は店舗を物理削除する。
+// DeleteStore は店舗を論理削除する。復元・完全削除はゴミ箱 API から行う。
+// 紐づくアンケートの扱いは policy=block|cascade|reassign で指定でき、未指定の場合はサーバーの既定ポリシーに従う。
+// reassign の場合は reassignTo に付け替え先の店舗 ID を指定する。
 func (h *handler) DeleteStore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := parseStoreID(chi.URLParam(r, "storeID"))
@@ -375,13 +639,32 @@ func (h *handler) DeleteStore(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	opts, err := storeDeleteOptionsFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	if err := h.storeService.Delete(ctx, id); err != nil {
+	before, err := h.storeService.FindByID(ctx, id)
+	if err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if before == nil {
+		respondError(w, http.StatusNotFound, "store not found")
+		return
+	}
+	beforeSnapshot := auditSnapshot(newStoreResponse(before))
 
-	w.WriteHeader(http.StatusNoContent)
+	result, err := h.storeService.Delete(ctx, id, opts)
+	if err != nil {
+		respondStoreDeleteError(w, err)
+		return
+	}
+
+	h.recordAudit(r, audit_domain.EntityStore, id.Value(), audit_domain.ActionDelete, beforeSnapshot, auditSnapshot(newStoreResponse(result.Store)))
+
+	respondJSON(w, http.StatusOK, newStoreDeleteResponse(result))
 }
 
 // GetStoreByID は店舗 ID で 1 件取得する。
@@ -411,8 +694,12 @@ func (h *handler) GetStoreByID(w http.ResponseWriter, r *http.Request) {
 func (h *handler) ListStores(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	query := r.URL.Query()
-	pagination := paginationFromRequest(r)
-	sortKey, err := sortKeyFromQuery(query.Get("sort"))
+	pagination, err := cursorPaginationFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sortKey, err := sortKeyForPagination(query.Get("sort"), pagination)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -424,21 +711,25 @@ func (h *handler) ListStores(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stores, total, err := h.storeService.Search(ctx, filter, sortKey, pagination)
+	stores, info, err := h.storeService.Search(ctx, filter, sortKey, pagination)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondListError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newStoreListResponse(stores, pagination, total))
+	respondJSON(w, http.StatusOK, newStorePageResponse(stores, pagination, info))
 }
 
 // ListAdminStores は管理画面向けに柔軟な条件で店舗一覧を返す。
 func (h *handler) ListAdminStores(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	pagination := paginationFromRequest(r)
+	pagination, err := cursorPaginationFromRequest(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	sortKey, err := sortKeyFromQuery(r.URL.Query().Get("sort"))
+	sortKey, err := sortKeyForPagination(r.URL.Query().Get("sort"), pagination)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -450,13 +741,13 @@ func (h *handler) ListAdminStores(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stores, total, err := h.storeService.Search(ctx, filter, sortKey, pagination)
+	stores, info, err := h.storeService.Search(ctx, filter, sortKey, pagination)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondListError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, newStoreListResponse(stores, pagination, total))
+	respondJSON(w, http.StatusOK, newStorePageResponse(stores, pagination, info))
 }
 
 // paginationFromRequest は page/limit クエリを安全に VO へ変換する。
@@ -467,6 +758,50 @@ func paginationFromRequest(r *http.Request) common_vo.Pagination {
 	return common_vo.NewPagination(page, limit)
 }
 
+// cursorPaginationFromRequest は cursor クエリがあればカーソルの位置から limit 件を、なければ page/limit を VO へ変換する。
+// cursor には一覧の応答の nextCursor/prevCursor をそのまま指定する。cursor を指定した場合 page は無視する。
+func cursorPaginationFromRequest(r *http.Request) (common_vo.Pagination, error) {
+	query := r.URL.Query()
+	token := strings.TrimSpace(query.Get("cursor"))
+	if token == "" {
+		return paginationFromRequest(r), nil
+	}
+	cursor, err := common_vo.ParseCursor(token)
+	if err != nil {
+		return common_vo.Pagination{}, err
+	}
+	return common_vo.NewCursorPagination(cursor, parseQueryInt(query.Get("limit"))), nil
+}
+
+// sortKeyForPagination は sort クエリから並び順を返す。
+// カーソルで指定した場合、sort を省略するとカーソルを発行した一覧の並び順を使い、異なる並び順を指定するとエラーとする。
+func sortKeyForPagination(value string, page common_vo.Pagination) (common_vo.SortKey, error) {
+	if !page.UsesCursor() {
+		return sortKeyFromQuery(value)
+	}
+	cursorKey := page.Cursor().SortKey()
+	if strings.TrimSpace(value) == "" {
+		return cursorKey, nil
+	}
+	sortKey, err := sortKeyFromQuery(value)
+	if err != nil {
+		return common_vo.SortKey{}, err
+	}
+	if !sortKey.Equals(cursorKey) {
+		return common_vo.SortKey{}, common_vo.ErrInvalidCursor
+	}
+	return sortKey, nil
+}
+
+// respondListError は一覧の取得エラーを返す。カーソルが条件に合わない場合は 400 とする。
+func respondListError(w http.ResponseWriter, err error) {
+	if errors.Is(err, common_vo.ErrInvalidCursor) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondError(w, http.StatusInternalServerError, err.Error())
+}
+
 // parseQueryInt は空文字や不正な数値を 0 に丸めるユーティリティ。
 func parseQueryInt(value string) int {
 	if value == "" {
@@ -499,9 +834,34 @@ func sortKeyFromQuery(value string) (common_vo.SortKey, error) {
 	return common_vo.NewSortKey(value)
 }
 
+// buildSurveyAdminFilter はアンケート一覧のクエリから検索条件を組み立てる。各値は VO のコンストラクタで検証する。
+//
+//	storeId                              店舗 ID
+//	prefecture, industry                 店舗の都道府県・業種
+//	keyword                              店舗名・支店名の部分一致
+//	q                                    自由記述のコメントの全文検索
+//	status, anomalies                    審査状況、外れ値の指摘が未確認 (anomalies=pending)。管理画面向け
+//	workType                             勤務形態。カンマ区切りで複数指定でき、いずれかに一致するものに絞り込む
+//	ageMin, ageMax                       年齢の範囲
+//	specScoreMin, specScoreMax           スペックの範囲
+//	averageEarningMin, averageEarningMax 平均稼ぎ (万円) の範囲
+//	waitTimeMin, waitTimeMax             待機時間 (時間) の範囲
+//	ratingMin, ratingMax                 総評 (0〜5) の範囲
+//	visitedFrom, visitedTo               稼働時期 (YYYY-MM) の範囲
+//	hasImages                            true で画像付き、false で画像なしのアンケートに絞り込む
+//
+// 範囲はいずれも両端を含み、片方だけ指定することもできる。例: ageMin=20&ageMax=25&workType=出稼ぎ
 func buildSurveyAdminFilter(values url.Values) (survey_domain.AdminFilter, error) {
 	var filter survey_domain.AdminFilter
 
+	if v := strings.TrimSpace(values.Get("storeId")); v != "" {
+		storeID, err := store_vo.NewID(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.StoreID = &storeID
+	}
+
 	if v := strings.TrimSpace(values.Get("prefecture")); v != "" {
 		pref, err := store_vo.NewPrefecture(v)
 		if err != nil {
@@ -521,6 +881,64 @@ func buildSurveyAdminFilter(values url.Values) (survey_domain.AdminFilter, error
 	if kw := strings.TrimSpace(values.Get("keyword")); kw != "" {
 		filter.Keyword = kw
 	}
+
+	// q は自由記述のコメントの全文検索。結果は関連度順に並び、一致した部分の抜粋を highlights に含める。
+	if q := strings.TrimSpace(values.Get("q")); q != "" {
+		filter.Text = q
+	}
+
+	if v := strings.TrimSpace(values.Get("status")); v != "" {
+		status, err := survey_vo.NewStatus(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
+
+	// anomalies=pending で外れ値の指摘が未確認のアンケートに絞り込む。
+	switch v := strings.TrimSpace(values.Get("anomalies")); v {
+	case "":
+	case "pending":
+		filter.PendingAnomalies = true
+	default:
+		return filter, errors.New("anomalies は pending のみ指定できます")
+	}
+
+	var err error
+	if filter.WorkTypes, err = parseQueryList(values, "workType", survey_vo.NewWorkType); err != nil {
+		return filter, err
+	}
+	if filter.Age, err = parseQueryRange(values, "age", intBound(survey_vo.NewAge)); err != nil {
+		return filter, err
+	}
+	if filter.SpecScore, err = parseQueryRange(values, "specScore", intBound(survey_vo.NewSpecScore)); err != nil {
+		return filter, err
+	}
+	if filter.AverageEarning, err = parseQueryRange(values, "averageEarning", intBound(survey_vo.NewAverageEarning)); err != nil {
+		return filter, err
+	}
+	if filter.WaitTimeHours, err = parseQueryRange(values, "waitTime", intBound(survey_vo.NewWaitTimeHours)); err != nil {
+		return filter, err
+	}
+	if filter.Rating, err = parseQueryRange(values, "rating", floatBound(survey_vo.NewRating)); err != nil {
+		return filter, err
+	}
+	if v := strings.TrimSpace(values.Get("visitedFrom")); v != "" {
+		if filter.VisitedFrom, err = survey_vo.NewVisitedPeriod(v); err != nil {
+			return filter, fmt.Errorf("visitedFrom: %w", err)
+		}
+	}
+	if v := strings.TrimSpace(values.Get("visitedTo")); v != "" {
+		if filter.VisitedTo, err = survey_vo.NewVisitedPeriod(v); err != nil {
+			return filter, fmt.Errorf("visitedTo: %w", err)
+		}
+	}
+	if !filter.VisitedFrom.IsZero() && !filter.VisitedTo.IsZero() && filter.VisitedFrom.Value().After(filter.VisitedTo.Value()) {
+		return filter, errors.New("visitedFrom は visitedTo 以前の月を指定してください")
+	}
+	if filter.HasImages, err = parseQueryBool(values, "hasImages"); err != nil {
+		return filter, err
+	}
 	return filter, nil
 }
 
@@ -552,7 +970,7 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, errorResponse{Error: message})
 }
 
-func sendSurveyToMessenger(ctx context.Context, payload surveyRequest) {
+func sendSurveyToMessenger(ctx context.Context, submissionID string, payload surveyRequest, matches []store_usecase.Match) {
 	if messengerGatewayURL == "" {
 		return
 	}
@@ -579,6 +997,7 @@ func sendSurveyToMessenger(ctx context.Context, payload surveyRequest) {
 	}
 
 	lines := []string{
+		fmt.Sprintf("投稿ID: %s", submissionID),
 		fmt.Sprintf("店舗名: %s", formatOrNA(payload.StoreName)),
 		fmt.Sprintf("支店名: %s", formatOrNA(payload.BranchName)),
 		fmt.Sprintf("都道府県: %s", formatOrNA(payload.Prefecture)),
@@ -603,6 +1022,7 @@ func sendSurveyToMessenger(ctx context.Context, payload surveyRequest) {
 	if len(payload.ImageURLs) > 0 {
 		lines = append(lines, fmt.Sprintf("画像URL: %s", strings.Join(payload.ImageURLs, ", ")))
 	}
+	lines = append(lines, formatStoreMatches(matches)...)
 	lines = append(lines, fmt.Sprintf("アンケートを追加する: %s", adminStoresURL))
 
 	text := "【新規アンケート】\n" + strings.Join(lines, "\n")
@@ -647,6 +1067,29 @@ func sendSurveyToMessenger(ctx context.Context, payload surveyRequest) {
 	}
 }
 
+// formatStoreMatches は候補店舗を通知用の行に整形する。管理画面の店舗ページへのリンクを添える。
+func formatStoreMatches(matches []store_usecase.Match) []string {
+	if len(matches) == 0 {
+		return []string{"候補店舗: なし"}
+	}
+	lines := []string{"候補店舗:"}
+	for _, m := range matches {
+		label := m.Store.Name().Value()
+		if branch := m.Store.BranchName(); branch != nil {
+			label += " / " + branch.Value()
+		}
+		lines = append(lines, fmt.Sprintf("- %s (%s) 一致度 %.0f%% [%s] %s/%s",
+			label,
+			m.Store.Prefecture().Value(),
+			m.Score*100,
+			m.Confidence,
+			strings.TrimRight(adminStoresURL, "/"),
+			m.Store.ID().Value(),
+		))
+	}
+	return lines
+}
+
 func envOrDefault(key, fallback string) string {
 	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
 		return v
@@ -732,6 +1175,8 @@ func newStoreResponse(entity *store_domain.Store) storeResponse {
 		Prefecture:    entity.Prefecture().Value(),
 		Industry:      entity.Industry().Value(),
 		AverageRating: entity.AverageRating().Value(),
+		SurveyCount:   entity.Stats().SurveyCount(),
+		Stats:         newStoreStatsResponse(entity.Stats()),
 		CreatedAt:     entity.CreatedAt().Value(),
 		UpdatedAt:     entity.UpdatedAt().Value(),
 	}
@@ -765,16 +1210,16 @@ func newStoreResponse(entity *store_domain.Store) storeResponse {
 }
 
 func newStoreListResponse(entities []*store_domain.Store, page common_vo.Pagination, total int64) storeListResponse {
+	return newStorePageResponse(entities, page, common_vo.PageInfo{Total: &total})
+}
+
+// newStorePageResponse は件数と前後のページのカーソルを含む一覧の応答を返す。
+func newStorePageResponse(entities []*store_domain.Store, page common_vo.Pagination, info common_vo.PageInfo) storeListResponse {
 	items := make([]storeResponse, 0, len(entities))
 	for _, entity := range entities {
 		items = append(items, newStoreResponse(entity))
 	}
-	return storeListResponse{
-		Items: items,
-		Page:  page.Page(),
-		Limit: page.Limit(),
-		Total: total,
-	}
+	return storeListResponse{Items: items, pageResponse: newPageResponse(page, info)}
 }
 
 type storeRequest struct {
@@ -804,6 +1249,8 @@ type storeResponse struct {
 	UnitPrice     *string               `json:"unitPrice,omitempty"`
 	BusinessHours *businessHoursPayload `json:"businessHours,omitempty"`
 	AverageRating float64               `json:"averageRating"`
+	SurveyCount   int                   `json:"surveyCount"`
+	Stats         storeStatsResponse    `json:"stats"`
 	CreatedAt     time.Time             `json:"createdAt"`
 	UpdatedAt     time.Time             `json:"updatedAt"`
 	DeletedAt     *time.Time            `json:"deletedAt,omitempty"`
@@ -811,9 +1258,7 @@ type storeResponse struct {
 
 type storeListResponse struct {
 	Items []storeResponse `json:"items"`
-	Page  int             `json:"page"`
-	Limit int             `json:"limit"`
-	Total int64           `json:"total"`
+	pageResponse
 }
 
 type surveyRequest struct {
@@ -839,80 +1284,174 @@ type surveyRequest struct {
 }
 
 type surveyResponse struct {
-	ID                     string     `json:"id"`
-	StoreID                string     `json:"storeId"`
-	StoreName              string     `json:"storeName"`
-	StoreBranch            *string    `json:"storeBranch,omitempty"`
-	StorePrefecture        string     `json:"storePrefecture"`
-	StoreArea              *string    `json:"storeArea,omitempty"`
-	StoreIndustry          string     `json:"storeIndustry"`
-	StoreGenre             *string    `json:"storeGenre,omitempty"`
-	VisitedPeriod          string     `json:"visitedPeriod"`
-	WorkType               string     `json:"workType"`
-	Age                    int        `json:"age"`
-	SpecScore              int        `json:"specScore"`
-	WaitTimeHours          int        `json:"waitTimeHours"`
-	AverageEarning         int        `json:"averageEarning"`
-	Rating                 float64    `json:"rating"`
-	CustomerComment        *string    `json:"customerComment,omitempty"`
-	StaffComment           *string    `json:"staffComment,omitempty"`
-	WorkEnvironmentComment *string    `json:"workEnvironmentComment,omitempty"`
-	EtcComment             *string    `json:"etcComment,omitempty"`
-	CastBack               *string    `json:"castBack,omitempty"`
-	EmailAddress           *string    `json:"emailAddress,omitempty"`
-	ImageURLs              []string   `json:"imageUrls,omitempty"`
-	CreatedAt              time.Time  `json:"createdAt"`
-	UpdatedAt              time.Time  `json:"updatedAt"`
-	DeletedAt              *time.Time `json:"deletedAt,omitempty"`
+	ID                     string                   `json:"id"`
+	StoreID                string                   `json:"storeId"`
+	StoreName              string                   `json:"storeName"`
+	StoreBranch            *string                  `json:"storeBranch,omitempty"`
+	StorePrefecture        string                   `json:"storePrefecture"`
+	StoreArea              *string                  `json:"storeArea,omitempty"`
+	StoreIndustry          string                   `json:"storeIndustry"`
+	StoreGenre             *string                  `json:"storeGenre,omitempty"`
+	VisitedPeriod          string                   `json:"visitedPeriod"`
+	WorkType               string                   `json:"workType"`
+	Age                    int                      `json:"age"`
+	SpecScore              int                      `json:"specScore"`
+	WaitTimeHours          int                      `json:"waitTimeHours"`
+	AverageEarning         int                      `json:"averageEarning"`
+	Rating                 float64                  `json:"rating"`
+	CustomerComment        *string                  `json:"customerComment,omitempty"`
+	StaffComment           *string                  `json:"staffComment,omitempty"`
+	WorkEnvironmentComment *string                  `json:"workEnvironmentComment,omitempty"`
+	EtcComment             *string                  `json:"etcComment,omitempty"`
+	CastBack               *string                  `json:"castBack,omitempty"`
+	EmailAddress           *string                  `json:"emailAddress,omitempty"`
+	ImageURLs              []string                 `json:"imageUrls,omitempty"`
+	HelpfulCount           int                      `json:"helpfulCount"`
+	Highlights             []commentSnippetResponse `json:"highlights,omitempty"`
+	CreatedAt              time.Time                `json:"createdAt"`
+	UpdatedAt              time.Time                `json:"updatedAt"`
+	DeletedAt              *time.Time               `json:"deletedAt,omitempty"`
+}
+
+// adminSurveyResponse は管理画面向けのアンケート。公開 API の surveyResponse に審査の状況と外れ値の指摘を加える。
+// 審査した管理者などは公開 API に含めないため、管理 API と監査ログではこちらを使う。
+type adminSurveyResponse struct {
+	surveyResponse
+	Status               string                  `json:"status"`
+	ReviewedBy           string                  `json:"reviewedBy,omitempty"`
+	ReviewedAt           *time.Time              `json:"reviewedAt,omitempty"`
+	RejectionReason      string                  `json:"rejectionReason,omitempty"`
+	Anomalies            []surveyAnomalyResponse `json:"anomalies,omitempty"`
+	AnomaliesConfirmedBy string                  `json:"anomaliesConfirmedBy,omitempty"`
+	AnomaliesConfirmedAt *time.Time              `json:"anomaliesConfirmedAt,omitempty"`
+	AnomalyPending       bool                    `json:"anomalyPending,omitempty"`
+}
+
+type surveyAnomalyResponse struct {
+	Field     string  `json:"field"`
+	Reference string  `json:"reference"`
+	Value     float64 `json:"value"`
+	Median    float64 `json:"median"`
+	Lower     float64 `json:"lower"`
+	Upper     float64 `json:"upper"`
+	Score     float64 `json:"score"`
+}
+
+// commentSnippetResponse は全文検索で一致したコメントの抜粋。
+// segments を順につなげると抜粋になり、match が true の部分が検索語に一致した箇所となる。
+type commentSnippetResponse struct {
+	Field    string                   `json:"field"`
+	Segments []snippetSegmentResponse `json:"segments"`
+}
+
+type snippetSegmentResponse struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+type surveyRejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+// pageResponse は一覧の応答に共通するページ情報。page/total はカーソルで取得した場合は省略する。
+type pageResponse struct {
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
+
+func newPageResponse(page common_vo.Pagination, info common_vo.PageInfo) pageResponse {
+	return pageResponse{
+		Page:       page.Page(),
+		Limit:      page.Limit(),
+		Total:      info.Total,
+		NextCursor: info.Next.String(),
+		PrevCursor: info.Prev.String(),
+	}
 }
 
 type surveyListResponse struct {
 	Items []surveyResponse `json:"items"`
-	Page  int              `json:"page"`
-	Limit int              `json:"limit"`
-	Total int64            `json:"total"`
+	pageResponse
+}
+
+type adminSurveyListResponse struct {
+	Items []adminSurveyResponse `json:"items"`
+	pageResponse
 }
 
+// buildStoreSearchFilter は店舗一覧のクエリから検索条件を組み立てる。各値は VO のコンストラクタで検証する。
+//
+//	prefecture, area, industry, genre  カンマ区切りで複数指定でき、いずれかに一致する店舗に絞り込む
+//	                                   例: prefecture=東京都,神奈川県&industry=ソープ,箱ヘル
+//	name                               店舗名・支店名の部分一致
+//	ratingMin, ratingMax               平均総評 (0〜5) の範囲
+//	surveyCountMin, surveyCountMax     承認済みアンケート件数の範囲
+//	avgEarningMin, avgEarningMax       平均稼ぎ (0〜20 万円、小数可) の範囲
+//	avgWaitTimeMin, avgWaitTimeMax     平均待機時間 (1〜24 時間、小数可) の範囲
+//	hasBusinessHours                   true で営業時間が登録済み、false で未登録の店舗に絞り込む
+//
+// 範囲はいずれも両端を含み、片方だけ指定することもできる。例: ratingMin=4&surveyCountMin=3
 func buildStoreSearchFilter(values url.Values) (store_domain.SearchFilter, error) {
-	var filter store_domain.SearchFilter
+	var (
+		filter store_domain.SearchFilter
+		err    error
+	)
 
-	if v := strings.TrimSpace(values.Get("prefecture")); v != "" {
-		pref, err := store_vo.NewPrefecture(v)
-		if err != nil {
-			return store_domain.SearchFilter{}, err
-		}
-		filter.Prefecture = &pref
+	if filter.Prefectures, err = parseQueryList(values, "prefecture", store_vo.NewPrefecture); err != nil {
+		return store_domain.SearchFilter{}, err
 	}
-	if v := strings.TrimSpace(values.Get("area")); v != "" {
-		area, err := store_vo.NewArea(v)
-		if err != nil {
-			return store_domain.SearchFilter{}, err
-		}
-		filter.Area = &area
+	if filter.Areas, err = parseQueryList(values, "area", store_vo.NewArea); err != nil {
+		return store_domain.SearchFilter{}, err
 	}
-	if v := strings.TrimSpace(values.Get("industry")); v != "" {
-		industry, err := store_vo.NewIndustry(v)
-		if err != nil {
-			return store_domain.SearchFilter{}, err
-		}
-		filter.Industry = &industry
+	if filter.Industries, err = parseQueryList(values, "industry", store_vo.NewIndustry); err != nil {
+		return store_domain.SearchFilter{}, err
 	}
-	if v := strings.TrimSpace(values.Get("genre")); v != "" {
-		genre, err := store_vo.NewGenre(v)
-		if err != nil {
-			return store_domain.SearchFilter{}, err
-		}
-		filter.Genre = &genre
+	if filter.Genres, err = parseQueryList(values, "genre", store_vo.NewGenre); err != nil {
+		return store_domain.SearchFilter{}, err
 	}
 	if keyword := strings.TrimSpace(values.Get("name")); keyword != "" {
 		filter.NameKeyword = keyword
 	}
 
+	if filter.Rating, err = parseQueryRange(values, "rating", floatBound(store_vo.NewAverageRating)); err != nil {
+		return store_domain.SearchFilter{}, err
+	}
+	if filter.SurveyCount, err = parseQueryRange(values, "surveyCount", surveyCountBound); err != nil {
+		return store_domain.SearchFilter{}, err
+	}
+	// 平均稼ぎ・平均待機時間は小数になるため、アンケートの VO で丸めず回答の取りうる範囲で検証する。
+	if filter.AvgEarning, err = parseQueryRange(values, "avgEarning", floatRangeBound(survey_vo.MinAverageEarning, survey_vo.MaxAverageEarning)); err != nil {
+		return store_domain.SearchFilter{}, err
+	}
+	if filter.AvgWaitTime, err = parseQueryRange(values, "avgWaitTime", floatRangeBound(survey_vo.MinWaitTimeHours, survey_vo.MaxWaitTimeHours)); err != nil {
+		return store_domain.SearchFilter{}, err
+	}
+	if filter.HasBusinessHours, err = parseQueryBool(values, "hasBusinessHours"); err != nil {
+		return store_domain.SearchFilter{}, err
+	}
+
 	return filter, nil
 }
 
+// surveyCountBound はアンケート件数の範囲の端を店舗統計の VO で検証する。
+func surveyCountBound(raw string) (float64, error) {
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("整数を指定してください: %q", raw)
+	}
+	stats, err := store_vo.NewStats(n, 0, nil, nil, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	return float64(stats.SurveyCount()), nil
+}
+
 // buildSurveyEntity は店舗情報を読み出し、Survey 集約を構築する。
-func (h *handler) buildSurveyEntity(ctx context.Context, id survey_vo.ID, payload surveyRequest) (*survey_domain.Survey, error) {
+// extra は掲載ステータスなど、リクエスト以外から設定する項目に使う。
+func (h *handler) buildSurveyEntity(ctx context.Context, id survey_vo.ID, payload surveyRequest, extra ...survey_domain.Option) (*survey_domain.Survey, error) {
 	storeID, err := store_vo.NewID(payload.StoreID)
 	if err != nil {
 		return nil, err
@@ -1012,6 +1551,7 @@ func (h *handler) buildSurveyEntity(ctx context.Context, id survey_vo.ID, payloa
 		}
 		opts = append(opts, survey_domain.WithImageURLs(urls))
 	}
+	opts = append(opts, extra...)
 
 	return survey_domain.NewSurvey(
 		id,
@@ -1030,6 +1570,24 @@ func (h *handler) buildSurveyEntity(ctx context.Context, id survey_vo.ID, payloa
 	)
 }
 
+// moderationOptions は既存アンケートの審査結果と「参考になった」の件数を引き継ぐためのオプションを返す。
+// 件数はレスポンスに表示するためだけに引き継ぎ、保存時には書き込まれない。
+func moderationOptions(entity *survey_domain.Survey) []survey_domain.Option {
+	opts := []survey_domain.Option{
+		survey_domain.WithStatus(entity.Status()),
+		survey_domain.WithRejectionReason(entity.RejectionReason()),
+		survey_domain.WithHelpfulCount(entity.HelpfulCount()),
+		survey_domain.WithAnomalies(entity.Anomalies()),
+	}
+	if reviewed := entity.ReviewedAt(); reviewed != nil {
+		opts = append(opts, survey_domain.WithReview(entity.ReviewedBy(), *reviewed))
+	}
+	if confirmed := entity.AnomaliesConfirmedAt(); confirmed != nil {
+		opts = append(opts, survey_domain.WithAnomalyConfirmation(entity.AnomaliesConfirmedBy(), *confirmed))
+	}
+	return opts
+}
+
 // newSurveyResponse は Survey 集約を HTTP レスポンスに変換する。
 func newSurveyResponse(entity *survey_domain.Survey) surveyResponse {
 	resp := surveyResponse{
@@ -1045,6 +1603,7 @@ func newSurveyResponse(entity *survey_domain.Survey) surveyResponse {
 		WaitTimeHours:   entity.WaitTime().Value(),
 		AverageEarning:  entity.AverageEarning().Value(),
 		Rating:          entity.Rating().Value(),
+		HelpfulCount:    entity.HelpfulCount(),
 		CreatedAt:       entity.CreatedAt().Value(),
 		UpdatedAt:       entity.UpdatedAt().Value(),
 	}
@@ -1094,15 +1653,79 @@ func newSurveyResponse(entity *survey_domain.Survey) surveyResponse {
 	return resp
 }
 
-func newSurveyListResponse(entities []*survey_domain.Survey, page common_vo.Pagination, total int64) surveyListResponse {
+// newAdminSurveyResponse は Survey 集約を審査の状況を含む管理画面向けのレスポンスに変換する。
+func newAdminSurveyResponse(entity *survey_domain.Survey) adminSurveyResponse {
+	resp := adminSurveyResponse{
+		surveyResponse:       newSurveyResponse(entity),
+		Status:               entity.Status().Value(),
+		ReviewedBy:           entity.ReviewedBy(),
+		RejectionReason:      entity.RejectionReason(),
+		AnomaliesConfirmedBy: entity.AnomaliesConfirmedBy(),
+		AnomalyPending:       entity.HasPendingAnomalies(),
+	}
+	if reviewed := entity.ReviewedAt(); reviewed != nil {
+		value := reviewed.Value()
+		resp.ReviewedAt = &value
+	}
+	for _, a := range entity.Anomalies() {
+		resp.Anomalies = append(resp.Anomalies, surveyAnomalyResponse{
+			Field:     string(a.Field),
+			Reference: string(a.Reference),
+			Value:     a.Value,
+			Median:    a.Median,
+			Lower:     a.Lower,
+			Upper:     a.Upper,
+			Score:     a.Score,
+		})
+	}
+	if confirmed := entity.AnomaliesConfirmedAt(); confirmed != nil {
+		value := confirmed.Value()
+		resp.AnomaliesConfirmedAt = &value
+	}
+	return resp
+}
+
+// commentHighlights は全文検索の語句 query に一致したコメントの抜粋を返す。query が空の場合は nil。
+func commentHighlights(entity *survey_domain.Survey, query string) []commentSnippetResponse {
+	if query == "" {
+		return nil
+	}
+	var highlights []commentSnippetResponse
+	for _, snippet := range entity.CommentSnippets(query) {
+		item := commentSnippetResponse{
+			Field:    string(snippet.Field),
+			Segments: make([]snippetSegmentResponse, 0, len(snippet.Segments)),
+		}
+		for _, segment := range snippet.Segments {
+			item.Segments = append(item.Segments, snippetSegmentResponse{Text: segment.Text, Match: segment.Match})
+		}
+		highlights = append(highlights, item)
+	}
+	return highlights
+}
+
+// newSurveyPageResponse は公開 API の一覧の応答を返す。query は全文検索の語句で、一致したコメントの抜粋を各項目に加える。
+func newSurveyPageResponse(entities []*survey_domain.Survey, page common_vo.Pagination, info common_vo.PageInfo, query string) surveyListResponse {
 	items := make([]surveyResponse, 0, len(entities))
 	for _, survey := range entities {
-		items = append(items, newSurveyResponse(survey))
+		item := newSurveyResponse(survey)
+		item.Highlights = commentHighlights(survey, query)
+		items = append(items, item)
 	}
-	return surveyListResponse{
-		Items: items,
-		Page:  page.Page(),
-		Limit: page.Limit(),
-		Total: total,
+	return surveyListResponse{Items: items, pageResponse: newPageResponse(page, info)}
+}
+
+func newAdminSurveyListResponse(entities []*survey_domain.Survey, page common_vo.Pagination, total int64) adminSurveyListResponse {
+	return newAdminSurveyPageResponse(entities, page, common_vo.PageInfo{Total: &total}, "")
+}
+
+// newAdminSurveyPageResponse は管理画面向けの一覧の応答を返す。query は newSurveyPageResponse と同じ。
+func newAdminSurveyPageResponse(entities []*survey_domain.Survey, page common_vo.Pagination, info common_vo.PageInfo, query string) adminSurveyListResponse {
+	items := make([]adminSurveyResponse, 0, len(entities))
+	for _, survey := range entities {
+		item := newAdminSurveyResponse(survey)
+		item.Highlights = commentHighlights(survey, query)
+		items = append(items, item)
 	}
+	return adminSurveyListResponse{Items: items, pageResponse: newPageResponse(page, info)}
 }
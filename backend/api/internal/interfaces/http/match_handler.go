@@ -0,0 +1,89 @@
+package interfaces
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	submission_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/submission"
+	store_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/store"
+)
+
+// MatchStores は自由入力の店舗名・支店名・都道府県・業種から候補店舗を一致度順に返す。
+func (h *handler) MatchStores(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	matchQuery := store_usecase.MatchQuery{
+		Name:       strings.TrimSpace(query.Get("name")),
+		Branch:     strings.TrimSpace(query.Get("branch")),
+		Prefecture: strings.TrimSpace(query.Get("prefecture")),
+		Industry:   strings.TrimSpace(query.Get("industry")),
+	}
+	if matchQuery.Name == "" {
+		respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	matches, err := h.storeService.Match(r.Context(), matchQuery, parseQueryInt(query.Get("limit")))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newStoreMatchResponses(matches))
+}
+
+// MatchSubmissionStores は一般投稿の店舗情報から候補店舗を一致度順に返す。
+func (h *handler) MatchSubmissionStores(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseSubmissionID(chi.URLParam(r, "submissionID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	submission, err := h.submissionService.FindByID(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if submission == nil {
+		respondError(w, http.StatusNotFound, "submission not found")
+		return
+	}
+
+	matches, err := h.storeService.Match(ctx, newMatchQuery(submission.Payload()), parseQueryInt(r.URL.Query().Get("limit")))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newStoreMatchResponses(matches))
+}
+
+func newMatchQuery(p submission_domain.Payload) store_usecase.MatchQuery {
+	return store_usecase.MatchQuery{
+		Name:       p.StoreName,
+		Branch:     p.BranchName,
+		Prefecture: p.Prefecture,
+		Industry:   p.Industry,
+	}
+}
+
+func newStoreMatchResponses(matches []store_usecase.Match) []storeMatchResponse {
+	responses := make([]storeMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		responses = append(responses, storeMatchResponse{
+			Store:      newStoreResponse(m.Store),
+			Score:      m.Score,
+			Confidence: m.Confidence,
+		})
+	}
+	return responses
+}
+
+type storeMatchResponse struct {
+	Store      storeResponse `json:"store"`
+	Score      float64       `json:"score"`
+	Confidence string        `json:"confidence"`
+}
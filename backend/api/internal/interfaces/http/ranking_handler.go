@@ -0,0 +1,90 @@
+package interfaces
+
+import (
+	"net/http"
+	"strings"
+
+	statistics_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/statistics"
+)
+
+// GetRankings は都道府県・エリア・業種ごとに店舗を指標のベイズ平均で順位付けして返す。
+// groupBy=prefecture|area|industry は必須、metric=earning|rating|waitTime は省略時 earning。
+// group を指定するとそのグループ（例: 東京都）のみ、limit はグループごとの件数を表す。
+func (h *handler) GetRankings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	groupBy, err := statistics_usecase.ParseDimension(query.Get("groupBy"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	metric, err := statistics_usecase.ParseMetric(query.Get("metric"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groups, err := h.statisticsService.Rankings(r.Context(), statistics_usecase.RankingQuery{
+		GroupBy: groupBy,
+		Metric:  metric,
+		Group:   strings.TrimSpace(query.Get("group")),
+		Limit:   parseQueryInt(query.Get("limit")),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := rankingResponse{
+		GroupBy: string(groupBy),
+		Metric:  string(metric),
+		Groups:  make([]rankingGroupResponse, 0, len(groups)),
+	}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, newRankingGroupResponse(group))
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+type rankingResponse struct {
+	GroupBy string                 `json:"groupBy"`
+	Metric  string                 `json:"metric"`
+	Groups  []rankingGroupResponse `json:"groups"`
+}
+
+type rankingGroupResponse struct {
+	Key        string                 `json:"key"`
+	PriorMean  float64                `json:"priorMean"`
+	SampleSize int                    `json:"sampleSize"`
+	Entries    []rankingEntryResponse `json:"entries"`
+}
+
+type rankingEntryResponse struct {
+	Rank       int           `json:"rank"`
+	Store      storeResponse `json:"store"`
+	SampleSize int           `json:"sampleSize"`
+	Average    float64       `json:"average"`
+	Score      float64       `json:"score"`
+	Weight     float64       `json:"weight"`
+	Confidence string        `json:"confidence"`
+}
+
+func newRankingGroupResponse(group statistics_usecase.RankingGroup) rankingGroupResponse {
+	entries := make([]rankingEntryResponse, 0, len(group.Entries))
+	for _, entry := range group.Entries {
+		entries = append(entries, rankingEntryResponse{
+			Rank:       entry.Rank,
+			Store:      newStoreResponse(entry.Store),
+			SampleSize: entry.SampleSize,
+			Average:    entry.Average,
+			Score:      entry.Score,
+			Weight:     entry.Weight,
+			Confidence: entry.Confidence,
+		})
+	}
+	return rankingGroupResponse{
+		Key:        group.Key,
+		PriorMean:  group.PriorMean,
+		SampleSize: group.SampleSize,
+		Entries:    entries,
+	}
+}
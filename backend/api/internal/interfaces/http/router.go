@@ -3,11 +3,18 @@ package interfaces
 
 import (
 	"github.com/go-chi/chi/v5"
+
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+	"github.com/sngm3741/makoto-club-services/api/internal/infrastructure/auth"
 )
 
 // NewRouter は共通ミドルウェアを適用した上で、Store/Survey のルートを組み立てる。
 // allowedOrigins は CORS チェックに利用され、空の場合は全許可となる。
-func NewRouter(handler Handler, allowedOrigins []string) chi.Router {
+// /api/admin 配下は verifier による JWT 検証を必須とする。
+func NewRouter(handler Handler, allowedOrigins []string, verifier *auth.Verifier) chi.Router {
+	if verifier == nil {
+		panic("http router: token verifier is nil")
+	}
 	r := chi.NewRouter()
 
 	for _, mw := range DefaultMiddlewares(allowedOrigins) {
@@ -17,38 +24,89 @@ func NewRouter(handler Handler, allowedOrigins []string) chi.Router {
 	r.Route("/api", func(r chi.Router) {
 		r.Route("/stores", func(r chi.Router) {
 			r.Get("/", handler.ListStores)
+			r.Get("/compare", handler.CompareStores)
 			r.Route("/{storeID}", func(r chi.Router) {
 				r.Get("/", handler.GetStoreByID)
 				r.Get("/surveys", handler.GetSurveysByStoreID)
+				r.Get("/stats", handler.GetStoreStats)
+				r.Get("/trends", handler.GetStoreTrends)
 			})
 		})
 
-	r.Route("/surveys", func(r chi.Router) {
-		r.Get("/", handler.ListSurveys)
-		r.Post("/", handler.SubmitSurvey)
-		r.Route("/{surveyID}", func(r chi.Router) {
-			r.Get("/", handler.GetSurveyByID)
+		r.Get("/rankings", handler.GetRankings)
+		r.Get("/trends", handler.GetTrends)
+		r.Post("/estimate", handler.EstimateEarnings)
+
+		r.Route("/surveys", func(r chi.Router) {
+			r.Get("/", handler.ListSurveys)
+			r.Post("/", handler.SubmitSurvey)
+			r.Route("/{surveyID}", func(r chi.Router) {
+				r.Get("/", handler.GetSurveyByID)
+				r.Post("/helpful", handler.MarkSurveyHelpful)
+				r.Delete("/helpful", handler.UnmarkSurveyHelpful)
+			})
 		})
-	})
 
 		r.Route("/admin", func(r chi.Router) {
-			r.Route("/stores", func(r chi.Router) {
-				r.Get("/", handler.ListAdminStores)
-				r.Post("/", handler.CreateStore)
-				r.Route("/{storeID}", func(r chi.Router) {
-					r.Get("/", handler.GetStoreByID)
-					r.Put("/", handler.UpdateStore)
-					r.Delete("/", handler.DeleteStore)
+			// ログイン/リフレッシュはトークン取得前に呼ばれるため認証対象外とする。
+			r.Post("/login", handler.LoginAdmin)
+			r.Post("/refresh", handler.RefreshAdminToken)
+
+			r.Group(func(r chi.Router) {
+				r.Use(adminAuthMiddleware(verifier))
+
+				// 各ルートに必要な権限。ロールごとの付与内容は admin_vo.Role を参照。
+				r.Route("/stores", func(r chi.Router) {
+					r.With(requirePermission(admin_vo.PermissionStoreRead)).Get("/", handler.ListAdminStores)
+					r.With(requirePermission(admin_vo.PermissionStoreWrite)).Post("/", handler.CreateStore)
+					r.With(requirePermission(admin_vo.PermissionStoreRead)).Get("/match", handler.MatchStores)
+					r.With(requirePermission(admin_vo.PermissionStoreWrite)).Post("/stats/recalculate", handler.RecalculateStoreStats)
+					r.Route("/{storeID}", func(r chi.Router) {
+						r.With(requirePermission(admin_vo.PermissionStoreRead)).Get("/", handler.GetStoreByID)
+						r.With(requirePermission(admin_vo.PermissionStoreWrite)).Put("/", handler.UpdateStore)
+						r.With(requirePermission(admin_vo.PermissionStoreDelete)).Delete("/", handler.DeleteStore)
+					})
 				})
-			})
-			r.Route("/surveys", func(r chi.Router) {
-				r.Get("/", handler.ListAdminSurveys)
-				r.Post("/", handler.CreateSurvey)
-				r.Route("/{surveyID}", func(r chi.Router) {
-					r.Get("/", handler.GetAdminSurveyByID)
-					r.Put("/", handler.UpdateSurvey)
-					r.Delete("/", handler.DeleteSurvey)
+				r.Route("/surveys", func(r chi.Router) {
+					r.With(requirePermission(admin_vo.PermissionSurveyRead)).Get("/", handler.ListAdminSurveys)
+					r.With(requirePermission(admin_vo.PermissionSurveyWrite)).Post("/", handler.CreateSurvey)
+					r.Route("/{surveyID}", func(r chi.Router) {
+						r.With(requirePermission(admin_vo.PermissionSurveyRead)).Get("/", handler.GetAdminSurveyByID)
+						r.With(requirePermission(admin_vo.PermissionSurveyWrite)).Put("/", handler.UpdateSurvey)
+						r.With(requirePermission(admin_vo.PermissionSurveyDelete)).Delete("/", handler.DeleteSurvey)
+						r.With(requirePermission(admin_vo.PermissionSurveyModerate)).Post("/approve", handler.ApproveSurvey)
+						r.With(requirePermission(admin_vo.PermissionSurveyModerate)).Post("/reject", handler.RejectSurvey)
+						r.With(requirePermission(admin_vo.PermissionSurveyModerate)).Post("/anomalies/confirm", handler.ConfirmSurveyAnomalies)
+					})
+				})
+				r.Route("/submissions", func(r chi.Router) {
+					r.With(requirePermission(admin_vo.PermissionSurveyRead)).Get("/", handler.ListSubmissions)
+					r.Route("/{submissionID}", func(r chi.Router) {
+						r.With(requirePermission(admin_vo.PermissionSurveyRead)).Get("/", handler.GetSubmissionByID)
+						r.With(requirePermission(admin_vo.PermissionStoreRead)).Get("/matches", handler.MatchSubmissionStores)
+						r.With(requirePermission(admin_vo.PermissionSurveyWrite)).Post("/convert", handler.ConvertSubmission)
+						r.With(requirePermission(admin_vo.PermissionSurveyWrite)).Post("/reject", handler.RejectSubmission)
+					})
 				})
+				r.Route("/trash", func(r chi.Router) {
+					r.With(requirePermission(admin_vo.PermissionTrashManage)).Get("/", handler.ListTrash)
+					r.With(requirePermission(admin_vo.PermissionTrashManage)).Post("/stores/{storeID}/restore", handler.RestoreStore)
+					r.With(requirePermission(admin_vo.PermissionTrashManage)).Post("/surveys/{surveyID}/restore", handler.RestoreSurvey)
+					r.With(requirePermission(admin_vo.PermissionTrashPurge)).Delete("/stores/{storeID}", handler.PurgeStore)
+					r.With(requirePermission(admin_vo.PermissionTrashPurge)).Delete("/surveys/{surveyID}", handler.PurgeSurvey)
+				})
+				r.Route("/accounts", func(r chi.Router) {
+					r.Use(requirePermission(admin_vo.PermissionAdminManage))
+					r.Get("/", handler.ListAdmins)
+					r.Post("/", handler.CreateAdmin)
+					r.Put("/{adminID}/role", handler.UpdateAdminRole)
+				})
+
+				r.With(requirePermission(admin_vo.PermissionAuditRead)).Get("/audit-logs", handler.ListAuditLogs)
+				r.With(
+					requirePermission(admin_vo.PermissionStoreRead),
+					requirePermission(admin_vo.PermissionSurveyRead),
+				).Get("/dashboard", handler.GetDashboard)
 			})
 		})
 	})
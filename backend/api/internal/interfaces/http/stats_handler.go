@@ -0,0 +1,143 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	statistics_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/statistics"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	statistics_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/statistics"
+)
+
+// GetStoreStats は店舗のアンケートから求めた項目ごとの分布を返す。
+// 全体と勤務形態（在籍/出稼ぎ）ごとに集計し、件数が最小件数に満たない集計単位は値を伏せる。
+func (h *handler) GetStoreStats(w http.ResponseWriter, r *http.Request) {
+	id, err := parseStoreID(chi.URLParam(r, "storeID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.statisticsService.StoreDistributions(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, statistics_usecase.ErrStoreNotFound) {
+			respondError(w, http.StatusNotFound, "store not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newStoreDistributionsResponse(result))
+}
+
+// RecalculateStoreStats は全店舗の統計をアンケートから再集計する。
+// 通常はアンケートの変更時に自動で更新されるため、集計のずれを修復する場合に使う。
+func (h *handler) RecalculateStoreStats(w http.ResponseWriter, r *http.Request) {
+	result, err := h.storeService.RecalculateStats(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, storeStatsRecalculateResponse{
+		Stores:            result.Stores,
+		StoresWithSurveys: result.StoresWithSurveys,
+	})
+}
+
+type storeStatsResponse struct {
+	SurveyCount    int        `json:"surveyCount"`
+	AvgRating      *float64   `json:"avgRating"`
+	AvgEarning     *float64   `json:"avgEarning"`
+	AvgWaitTime    *float64   `json:"avgWaitTime"`
+	LastSurveyedAt *time.Time `json:"lastSurveyedAt"`
+}
+
+func newStoreStatsResponse(stats store_vo.Stats) storeStatsResponse {
+	return storeStatsResponse{
+		SurveyCount:    stats.SurveyCount(),
+		AvgRating:      stats.AvgRating(),
+		AvgEarning:     stats.AvgEarning(),
+		AvgWaitTime:    stats.AvgWaitTime(),
+		LastSurveyedAt: stats.LastSurveyedAt(),
+	}
+}
+
+type storeStatsRecalculateResponse struct {
+	Stores            int `json:"stores"`
+	StoresWithSurveys int `json:"storesWithSurveys"`
+}
+
+type storeDistributionsResponse struct {
+	StoreID       string                               `json:"storeId"`
+	MinSampleSize int                                  `json:"minSampleSize"`
+	All           distributionGroupResponse            `json:"all"`
+	ByWorkType    map[string]distributionGroupResponse `json:"byWorkType"`
+}
+
+type distributionGroupResponse struct {
+	SampleSize int                             `json:"sampleSize"`
+	Withheld   bool                            `json:"withheld"`
+	Fields     map[string]distributionResponse `json:"fields,omitempty"`
+}
+
+type distributionResponse struct {
+	Count   int              `json:"count"`
+	Mean    float64          `json:"mean"`
+	Min     float64          `json:"min"`
+	Max     float64          `json:"max"`
+	P25     float64          `json:"p25"`
+	Median  float64          `json:"median"`
+	P75     float64          `json:"p75"`
+	Buckets []bucketResponse `json:"buckets"`
+}
+
+type bucketResponse struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+func newStoreDistributionsResponse(result statistics_usecase.StoreDistributions) storeDistributionsResponse {
+	resp := storeDistributionsResponse{
+		StoreID:       result.StoreID.Value(),
+		MinSampleSize: result.MinSampleSize,
+		All:           newDistributionGroupResponse(result.All),
+		ByWorkType:    make(map[string]distributionGroupResponse, len(result.ByWorkType)),
+	}
+	for workType, group := range result.ByWorkType {
+		resp.ByWorkType[workType] = newDistributionGroupResponse(group)
+	}
+	return resp
+}
+
+func newDistributionGroupResponse(group statistics_usecase.DistributionGroup) distributionGroupResponse {
+	resp := distributionGroupResponse{SampleSize: group.SampleSize, Withheld: group.Withheld}
+	if len(group.Fields) > 0 {
+		resp.Fields = make(map[string]distributionResponse, len(group.Fields))
+		for field, dist := range group.Fields {
+			resp.Fields[string(field)] = newDistributionResponse(dist)
+		}
+	}
+	return resp
+}
+
+func newDistributionResponse(dist statistics_domain.Distribution) distributionResponse {
+	buckets := make([]bucketResponse, 0, len(dist.Buckets))
+	for _, b := range dist.Buckets {
+		buckets = append(buckets, bucketResponse{Lower: b.Lower, Upper: b.Upper, Count: b.Count})
+	}
+	return distributionResponse{
+		Count:   dist.Count,
+		Mean:    dist.Mean,
+		Min:     dist.Min,
+		Max:     dist.Max,
+		P25:     dist.P25,
+		Median:  dist.Median,
+		P75:     dist.P75,
+		Buckets: buckets,
+	}
+}
@@ -0,0 +1,69 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	store_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/store"
+)
+
+// storeDeleteOptionsFromRequest は policy/reassignTo クエリから店舗削除の指定を組み立てる。
+func storeDeleteOptionsFromRequest(r *http.Request) (store_usecase.DeleteOptions, error) {
+	query := r.URL.Query()
+	var opts store_usecase.DeleteOptions
+
+	if raw := strings.TrimSpace(query.Get("policy")); raw != "" {
+		policy, err := store_usecase.ParseDeletePolicy(raw)
+		if err != nil {
+			return store_usecase.DeleteOptions{}, err
+		}
+		opts.Policy = policy
+	}
+	if raw := strings.TrimSpace(query.Get("reassignTo")); raw != "" {
+		target, err := parseStoreID(raw)
+		if err != nil {
+			return store_usecase.DeleteOptions{}, fmt.Errorf("reassignTo: %w", err)
+		}
+		opts.ReassignTo = &target
+	}
+	return opts, nil
+}
+
+// respondStoreDeleteError は店舗削除のエラーを HTTP ステータスに変換する。
+func respondStoreDeleteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store_usecase.ErrStoreNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, store_usecase.ErrStoreHasSurveys), errors.Is(err, store_domain.ErrAlreadyDeleted):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, store_usecase.ErrInvalidDeletePolicy),
+		errors.Is(err, store_usecase.ErrReassignTargetRequired),
+		errors.Is(err, store_usecase.ErrInvalidReassignTarget):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type storeDeleteResponse struct {
+	Store           storeResponse  `json:"store"`
+	Policy          string         `json:"policy"`
+	AffectedSurveys int64          `json:"affectedSurveys"`
+	ReassignedTo    *storeResponse `json:"reassignedTo,omitempty"`
+}
+
+func newStoreDeleteResponse(result store_usecase.DeleteResult) storeDeleteResponse {
+	resp := storeDeleteResponse{
+		Store:           newStoreResponse(result.Store),
+		Policy:          string(result.Policy),
+		AffectedSurveys: result.AffectedSurveys,
+	}
+	if result.ReassignedTo != nil {
+		target := newStoreResponse(result.ReassignedTo)
+		resp.ReassignedTo = &target
+	}
+	return resp
+}
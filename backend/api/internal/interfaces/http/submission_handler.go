@@ -0,0 +1,303 @@
+package interfaces
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	audit_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/audit"
+	submission_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/submission"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	submission_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/submission"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	submission_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/submission"
+)
+
+// ListSubmissions は一般投稿の一覧を返す。status クエリで絞り込める。
+func (h *handler) ListSubmissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pagination := paginationFromRequest(r)
+
+	filter, err := buildSubmissionFilter(r.URL.Query())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	submissions, total, err := h.submissionService.List(ctx, filter, pagination)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newSubmissionListResponse(submissions, pagination, total))
+}
+
+// GetSubmissionByID は一般投稿を 1 件返す。
+func (h *handler) GetSubmissionByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseSubmissionID(chi.URLParam(r, "submissionID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	submission, err := h.submissionService.FindByID(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if submission == nil {
+		respondError(w, http.StatusNotFound, "submission not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newSubmissionResponse(submission))
+}
+
+// ConvertSubmission は一般投稿を指定店舗のアンケートとして登録する。
+// 投稿内容はそのまま使い、店舗情報のみリクエストの storeId から補う。
+// 登録したアンケートは承認待ちとなり、承認されるまで公開 API には表示されない。
+func (h *handler) ConvertSubmission(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseSubmissionID(chi.URLParam(r, "submissionID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var payload submissionConvertRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	submission, err := h.submissionService.FindByID(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if submission == nil {
+		respondError(w, http.StatusNotFound, "submission not found")
+		return
+	}
+
+	request := newSurveyRequestFromPayload(submission.Payload())
+	request.StoreID = strings.TrimSpace(payload.StoreID)
+
+	surveyID, err := survey_vo.NewID(primitive.NewObjectID().Hex())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	pending, _ := survey_vo.NewStatus(survey_vo.StatusPending)
+	survey, err := h.buildSurveyEntity(ctx, surveyID, request, survey_domain.WithStatus(pending))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, _ := AdminSubjectFromContext(ctx)
+	submission, err = h.submissionService.Convert(ctx, id, survey, actor)
+	if err != nil {
+		respondSubmissionError(w, err)
+		return
+	}
+
+	surveyResp := newAdminSurveyResponse(survey)
+	h.recordAudit(r, audit_domain.EntitySurvey, surveyID.Value(), audit_domain.ActionCreate, nil, auditSnapshot(surveyResp))
+	respondJSON(w, http.StatusCreated, submissionConvertResponse{
+		Submission: newSubmissionResponse(submission),
+		Survey:     surveyResp,
+	})
+}
+
+// RejectSubmission は一般投稿を却下する。
+func (h *handler) RejectSubmission(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseSubmissionID(chi.URLParam(r, "submissionID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var payload submissionRejectRequest
+	if err := decodeJSON(r, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, _ := AdminSubjectFromContext(ctx)
+	submission, err := h.submissionService.Reject(ctx, id, actor, payload.Reason)
+	if err != nil {
+		respondSubmissionError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newSubmissionResponse(submission))
+}
+
+// respondSubmissionError は投稿の変換・却下で発生したエラーをステータスコードに対応付ける。
+func respondSubmissionError(w http.ResponseWriter, err error) {
+	if respondDuplicateError(w, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, submission_usecase.ErrSubmissionNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, submission_domain.ErrNotPending):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// parseSubmissionID は URL パラメータから投稿 ID VO を生成する。
+func parseSubmissionID(value string) (submission_vo.ID, error) {
+	if value == "" {
+		return submission_vo.ID{}, errors.New("submissionID is required")
+	}
+	return submission_vo.NewID(value)
+}
+
+// clientIP は送信元 IP を返す。middleware.RealIP により X-Forwarded-For 等が反映済みの RemoteAddr を使う。
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func buildSubmissionFilter(values url.Values) (submission_domain.Filter, error) {
+	var filter submission_domain.Filter
+	if v := strings.TrimSpace(values.Get("status")); v != "" {
+		status, err := submission_vo.NewStatus(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
+	return filter, nil
+}
+
+func newSubmissionPayload(req surveyRequest) submission_domain.Payload {
+	return submission_domain.Payload{
+		StoreName:              req.StoreName,
+		BranchName:             req.BranchName,
+		Prefecture:             req.Prefecture,
+		Industry:               req.Industry,
+		StoreID:                req.StoreID,
+		VisitedPeriod:          req.VisitedPeriod,
+		WorkType:               req.WorkType,
+		Age:                    req.Age,
+		SpecScore:              req.SpecScore,
+		WaitTimeHours:          req.WaitTimeHours,
+		AverageEarning:         req.AverageEarning,
+		Rating:                 req.Rating,
+		CustomerComment:        req.CustomerComment,
+		StaffComment:           req.StaffComment,
+		WorkEnvironmentComment: req.WorkEnvironmentComment,
+		EtcComment:             req.EtcComment,
+		CastBack:               req.CastBack,
+		EmailAddress:           req.EmailAddress,
+		ImageURLs:              req.ImageURLs,
+	}
+}
+
+func newSurveyRequestFromPayload(p submission_domain.Payload) surveyRequest {
+	return surveyRequest{
+		StoreName:              p.StoreName,
+		BranchName:             p.BranchName,
+		Prefecture:             p.Prefecture,
+		Industry:               p.Industry,
+		StoreID:                p.StoreID,
+		VisitedPeriod:          p.VisitedPeriod,
+		WorkType:               p.WorkType,
+		Age:                    p.Age,
+		SpecScore:              p.SpecScore,
+		WaitTimeHours:          p.WaitTimeHours,
+		AverageEarning:         p.AverageEarning,
+		Rating:                 p.Rating,
+		CustomerComment:        p.CustomerComment,
+		StaffComment:           p.StaffComment,
+		WorkEnvironmentComment: p.WorkEnvironmentComment,
+		EtcComment:             p.EtcComment,
+		CastBack:               p.CastBack,
+		EmailAddress:           p.EmailAddress,
+		ImageURLs:              p.ImageURLs,
+	}
+}
+
+// newSubmissionResponse は Submission 集約を HTTP レスポンスに変換する。
+func newSubmissionResponse(entity *submission_domain.Submission) submissionResponse {
+	resp := submissionResponse{
+		ID:              entity.ID().Value(),
+		Payload:         newSurveyRequestFromPayload(entity.Payload()),
+		ClientIP:        entity.ClientIP(),
+		Status:          entity.Status().Value(),
+		ProcessedBy:     entity.ProcessedBy(),
+		RejectionReason: entity.RejectionReason(),
+		ReceivedAt:      entity.ReceivedAt().Value(),
+	}
+	if surveyID := entity.SurveyID(); surveyID != nil {
+		resp.SurveyID = surveyID.Value()
+	}
+	if processedAt := entity.ProcessedAt(); processedAt != nil {
+		value := processedAt.Value()
+		resp.ProcessedAt = &value
+	}
+	return resp
+}
+
+func newSubmissionListResponse(entities []*submission_domain.Submission, page common_vo.Pagination, total int64) submissionListResponse {
+	items := make([]submissionResponse, 0, len(entities))
+	for _, entity := range entities {
+		items = append(items, newSubmissionResponse(entity))
+	}
+	return submissionListResponse{
+		Items: items,
+		Page:  page.Page(),
+		Limit: page.Limit(),
+		Total: total,
+	}
+}
+
+type submissionConvertRequest struct {
+	StoreID string `json:"storeId"`
+}
+
+type submissionRejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+type submissionResponse struct {
+	ID              string        `json:"id"`
+	Payload         surveyRequest `json:"payload"`
+	ClientIP        string        `json:"clientIp,omitempty"`
+	Status          string        `json:"status"`
+	SurveyID        string        `json:"surveyId,omitempty"`
+	ProcessedBy     string        `json:"processedBy,omitempty"`
+	ProcessedAt     *time.Time    `json:"processedAt,omitempty"`
+	RejectionReason string        `json:"rejectionReason,omitempty"`
+	ReceivedAt      time.Time     `json:"receivedAt"`
+}
+
+type submissionListResponse struct {
+	Items []submissionResponse `json:"items"`
+	Page  int                  `json:"page"`
+	Limit int                  `json:"limit"`
+	Total int64                `json:"total"`
+}
+
+type submissionConvertResponse struct {
+	Submission submissionResponse  `json:"submission"`
+	Survey     adminSurveyResponse `json:"survey"`
+}
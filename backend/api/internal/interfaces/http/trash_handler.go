@@ -0,0 +1,174 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	audit_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/audit"
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	store_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/store"
+	survey_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/survey"
+)
+
+const (
+	trashTypeStore  = "store"
+	trashTypeSurvey = "survey"
+)
+
+// ListTrash は論理削除された店舗・アンケートを削除日時の新しい順に返す。
+// type=store|survey で片方に絞り込める。ページングはそれぞれの一覧に個別に適用する。
+func (h *handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pagination := paginationFromRequest(r)
+
+	trashType := strings.TrimSpace(r.URL.Query().Get("type"))
+	if trashType != "" && trashType != trashTypeStore && trashType != trashTypeSurvey {
+		respondError(w, http.StatusBadRequest, "type must be store or survey")
+		return
+	}
+
+	var resp trashResponse
+	if trashType == "" || trashType == trashTypeStore {
+		stores, total, err := h.storeService.ListDeleted(ctx, pagination)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		list := newStoreListResponse(stores, pagination, total)
+		resp.Stores = &list
+	}
+	if trashType == "" || trashType == trashTypeSurvey {
+		surveys, total, err := h.surveyService.ListDeleted(ctx, pagination)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		list := newAdminSurveyListResponse(surveys, pagination, total)
+		resp.Surveys = &list
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RestoreStore はゴミ箱の店舗を復元する。
+func (h *handler) RestoreStore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseStoreID(chi.URLParam(r, "storeID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before, err := h.storeService.FindByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if before == nil {
+		respondError(w, http.StatusNotFound, "store not found")
+		return
+	}
+	beforeSnapshot := auditSnapshot(newStoreResponse(before))
+
+	store, err := h.storeService.Restore(ctx, id)
+	if err != nil {
+		respondTrashError(w, err)
+		return
+	}
+
+	response := newStoreResponse(store)
+	h.recordAudit(r, audit_domain.EntityStore, id.Value(), audit_domain.ActionRestore, beforeSnapshot, auditSnapshot(response))
+	respondJSON(w, http.StatusOK, response)
+}
+
+// RestoreSurvey はゴミ箱のアンケートを復元する。紐づく店舗がゴミ箱にある場合は 409 とする。
+func (h *handler) RestoreSurvey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before, err := h.surveyService.FindByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if before == nil {
+		respondError(w, http.StatusNotFound, "survey not found")
+		return
+	}
+	beforeSnapshot := auditSnapshot(newAdminSurveyResponse(before))
+
+	survey, err := h.surveyService.Restore(ctx, id)
+	if err != nil {
+		respondTrashError(w, err)
+		return
+	}
+
+	response := newAdminSurveyResponse(survey)
+	h.recordAudit(r, audit_domain.EntitySurvey, id.Value(), audit_domain.ActionRestore, beforeSnapshot, auditSnapshot(response))
+	respondJSON(w, http.StatusOK, response)
+}
+
+// PurgeStore はゴミ箱の店舗を物理削除する。ゴミ箱にない店舗と、ゴミ箱にないアンケートが紐づく店舗は 409 とする。
+func (h *handler) PurgeStore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseStoreID(chi.URLParam(r, "storeID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	purged, err := h.storeService.Purge(ctx, id)
+	if err != nil {
+		respondTrashError(w, err)
+		return
+	}
+
+	h.recordAudit(r, audit_domain.EntityStore, id.Value(), audit_domain.ActionPurge, auditSnapshot(newStoreResponse(purged)), nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeSurvey はゴミ箱のアンケートを物理削除する。ゴミ箱にないアンケートは 409 とする。
+func (h *handler) PurgeSurvey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	purged, err := h.surveyService.Purge(ctx, id)
+	if err != nil {
+		respondTrashError(w, err)
+		return
+	}
+
+	h.recordAudit(r, audit_domain.EntitySurvey, id.Value(), audit_domain.ActionPurge, auditSnapshot(newAdminSurveyResponse(purged)), nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondTrashError は削除・復元・完全削除で発生したエラーをステータスコードに対応付ける。
+func respondTrashError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store_usecase.ErrStoreNotFound), errors.Is(err, survey_usecase.ErrSurveyNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, store_domain.ErrAlreadyDeleted), errors.Is(err, store_domain.ErrNotDeleted),
+		errors.Is(err, survey_domain.ErrAlreadyDeleted), errors.Is(err, survey_domain.ErrNotDeleted),
+		errors.Is(err, survey_usecase.ErrStoreDeleted), errors.Is(err, store_usecase.ErrStoreHasLiveSurveys):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+type trashResponse struct {
+	Stores  *storeListResponse       `json:"stores,omitempty"`
+	Surveys *adminSurveyListResponse `json:"surveys,omitempty"`
+}
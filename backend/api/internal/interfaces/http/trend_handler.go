@@ -0,0 +1,166 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	statistics_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/statistics"
+)
+
+// GetStoreTrends は店舗のアンケートを稼働時期ごとに集計し、平均稼ぎ・総評・待機時間の推移を返す。
+// from/to は "YYYY-MM" 形式、granularity=month|quarter は省略時 month。
+func (h *handler) GetStoreTrends(w http.ResponseWriter, r *http.Request) {
+	id, err := parseStoreID(chi.URLParam(r, "storeID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rng, err := trendRangeFromQuery(r.URL.Query())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	trend, err := h.statisticsService.StoreTrend(r.Context(), id, rng)
+	if err != nil {
+		respondTrendError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newTrendResponse(trend))
+}
+
+// GetTrends はエリア、または都道府県と業種で絞り込んだアンケートの推移を返す。
+// area か prefecture+industry のいずれかが必須で、期間の指定は GetStoreTrends と同じ。
+func (h *handler) GetTrends(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	scope, err := trendScopeFromQuery(query)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rng, err := trendRangeFromQuery(query)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	trend, err := h.statisticsService.ScopeTrend(r.Context(), scope, rng)
+	if err != nil {
+		respondTrendError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, newTrendResponse(trend))
+}
+
+func respondTrendError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, statistics_usecase.ErrStoreNotFound):
+		respondError(w, http.StatusNotFound, "store not found")
+	case errors.Is(err, statistics_usecase.ErrInvalidTrendRange), errors.Is(err, statistics_usecase.ErrInvalidTrendScope):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func trendRangeFromQuery(values url.Values) (statistics_usecase.TrendRange, error) {
+	var rng statistics_usecase.TrendRange
+	granularity, err := statistics_usecase.ParseGranularity(values.Get("granularity"))
+	if err != nil {
+		return rng, err
+	}
+	rng.Granularity = granularity
+
+	if v := strings.TrimSpace(values.Get("from")); v != "" {
+		from, err := survey_vo.NewVisitedPeriod(v)
+		if err != nil {
+			return rng, err
+		}
+		rng.From = from
+	}
+	if v := strings.TrimSpace(values.Get("to")); v != "" {
+		to, err := survey_vo.NewVisitedPeriod(v)
+		if err != nil {
+			return rng, err
+		}
+		rng.To = to
+	}
+	return rng, nil
+}
+
+func trendScopeFromQuery(values url.Values) (statistics_usecase.TrendScope, error) {
+	var scope statistics_usecase.TrendScope
+	if v := strings.TrimSpace(values.Get("prefecture")); v != "" {
+		pref, err := store_vo.NewPrefecture(v)
+		if err != nil {
+			return scope, err
+		}
+		scope.Prefecture = &pref
+	}
+	if v := strings.TrimSpace(values.Get("area")); v != "" {
+		area, err := store_vo.NewArea(v)
+		if err != nil {
+			return scope, err
+		}
+		scope.Area = &area
+	}
+	if v := strings.TrimSpace(values.Get("industry")); v != "" {
+		industry, err := store_vo.NewIndustry(v)
+		if err != nil {
+			return scope, err
+		}
+		scope.Industry = &industry
+	}
+	return scope, nil
+}
+
+type trendResponse struct {
+	Granularity   string               `json:"granularity"`
+	From          string               `json:"from"`
+	To            string               `json:"to"`
+	MinSampleSize int                  `json:"minSampleSize"`
+	Points        []trendPointResponse `json:"points"`
+}
+
+type trendPointResponse struct {
+	Period          string   `json:"period"`
+	Start           string   `json:"start"`
+	SampleSize      int      `json:"sampleSize"`
+	Withheld        bool     `json:"withheld"`
+	AverageEarning  *float64 `json:"averageEarning,omitempty"`
+	AverageRating   *float64 `json:"averageRating,omitempty"`
+	AverageWaitTime *float64 `json:"averageWaitTime,omitempty"`
+}
+
+func newTrendResponse(trend statistics_usecase.Trend) trendResponse {
+	resp := trendResponse{
+		Granularity:   string(trend.Granularity),
+		From:          trend.From.Value().Format("2006-01"),
+		To:            trend.To.Value().Format("2006-01"),
+		MinSampleSize: trend.MinSampleSize,
+		Points:        make([]trendPointResponse, 0, len(trend.Points)),
+	}
+	for _, p := range trend.Points {
+		point := trendPointResponse{
+			Period:     p.Period,
+			Start:      p.Start.Value().Format("2006-01"),
+			SampleSize: p.SampleSize,
+			Withheld:   p.Withheld,
+		}
+		// 伏せた期間は 0 と区別できるよう平均値を出力しない。
+		if !p.Withheld {
+			earning, rating, wait := p.AverageEarning, p.AverageRating, p.AverageWaitTime
+			point.AverageEarning = &earning
+			point.AverageRating = &rating
+			point.AverageWaitTime = &wait
+		}
+		resp.Points = append(resp.Points, point)
+	}
+	return resp
+}
@@ -0,0 +1,71 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+	vote_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/vote"
+	vote_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/vote"
+)
+
+// deviceTokenHeader はフロントエンドが端末ごとに発行して送る識別子のヘッダー名。
+// 同じ IP アドレスを共有する別の端末を区別するために使う。
+const deviceTokenHeader = "X-Device-Token"
+
+// MarkSurveyHelpful はアンケートに「参考になった」を付ける。同じクライアントからの 2 回目以降は件数を変えない。
+func (h *handler) MarkSurveyHelpful(w http.ResponseWriter, r *http.Request) {
+	h.handleHelpfulVote(w, r, h.voteService.MarkHelpful)
+}
+
+// UnmarkSurveyHelpful は「参考になった」を取り消す。
+func (h *handler) UnmarkSurveyHelpful(w http.ResponseWriter, r *http.Request) {
+	h.handleHelpfulVote(w, r, h.voteService.UnmarkHelpful)
+}
+
+func (h *handler) handleHelpfulVote(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(context.Context, survey_vo.ID, vote_usecase.Voter) (vote_usecase.Result, error),
+) {
+	id, err := parseSurveyID(chi.URLParam(r, "surveyID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	voter := vote_usecase.Voter{
+		IP:          clientIP(r),
+		DeviceToken: strings.TrimSpace(r.Header.Get(deviceTokenHeader)),
+	}
+	result, err := action(r.Context(), id, voter)
+	if err != nil {
+		switch {
+		case errors.Is(err, vote_usecase.ErrSurveyNotFound):
+			respondError(w, http.StatusNotFound, "survey not found")
+		case errors.Is(err, vote_domain.ErrVoterUnknown):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, helpfulVoteResponse{
+		SurveyID:     result.SurveyID.Value(),
+		HelpfulCount: result.HelpfulCount,
+		Voted:        result.Voted,
+		Changed:      result.Changed,
+	})
+}
+
+type helpfulVoteResponse struct {
+	SurveyID     string `json:"surveyId"`
+	HelpfulCount int    `json:"helpfulCount"`
+	Voted        bool   `json:"voted"`
+	Changed      bool   `json:"changed"`
+}
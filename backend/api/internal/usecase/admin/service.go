@@ -0,0 +1,252 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	admin_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/admin"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+
+	admin_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/admin"
+)
+
+var (
+	// ErrInvalidCredentials はメールアドレスまたはパスワードが一致しない場合に返される。
+	// どちらが誤っているかは呼び出し側に伝えない。
+	ErrInvalidCredentials = errors.New("メールアドレスまたはパスワードが正しくありません")
+	// ErrEmailTaken は同じメールアドレスの管理者が既に存在する場合に返される。
+	ErrEmailTaken = errors.New("このメールアドレスの管理者は既に存在します")
+	// ErrAdminNotFound は指定した管理者が存在しない場合に返される。
+	ErrAdminNotFound = errors.New("管理者が見つかりません")
+	// ErrLastOwner は最後の owner のロールを変更しようとした場合に返される。
+	ErrLastOwner = errors.New("owner が 1 人もいなくなるため変更できません")
+	// ErrTokenIssuerUnavailable は署名鍵がなくトークンを発行できない構成でログイン/リフレッシュした場合に返される。
+	ErrTokenIssuerUnavailable = errors.New("この環境ではトークンを発行できません")
+)
+
+// TokenIssuer は管理者向けトークンの発行・検証を担う。
+type TokenIssuer interface {
+	IssueAccessToken(subject, role string) (string, time.Time, error)
+	IssueRefreshToken(subject string) (string, time.Time, error)
+	VerifyRefreshToken(raw string) (string, error)
+}
+
+// TokenPair はログイン/リフレッシュで返すトークンの組。
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// Service は管理者アカウントに関するアプリケーションサービス。
+type Service interface {
+	Login(ctx context.Context, email, password string) (*admin_domain.Admin, TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*admin_domain.Admin, TokenPair, error)
+	Bootstrap(ctx context.Context, email, password string) (bool, error)
+	Create(ctx context.Context, email, password string, role admin_vo.Role) (*admin_domain.Admin, error)
+	List(ctx context.Context) ([]*admin_domain.Admin, error)
+	ChangeRole(ctx context.Context, id admin_vo.ID, role admin_vo.Role) (*admin_domain.Admin, error)
+}
+
+type service struct {
+	repo   admin_domain.Repo
+	tokens TokenIssuer
+	// dummyHash はアカウントが存在しない場合にも照合コストをかけ、応答時間でアカウントの有無が漏れないようにする。
+	dummyHash admin_vo.PasswordHash
+}
+
+// NewService は AdminService を生成する。
+// 外部で発行したトークンを検証するだけの構成では tokens に nil を渡し、Login/Refresh は ErrTokenIssuerUnavailable を返す。
+func NewService(repo admin_domain.Repo, tokens TokenIssuer) Service {
+	if repo == nil {
+		panic("admin usecase: repo is nil")
+	}
+	dummy, err := admin_vo.HashPassword("makoto-club-dummy-password")
+	if err != nil {
+		panic("admin usecase: failed to prepare dummy hash: " + err.Error())
+	}
+	return &service{repo: repo, tokens: tokens, dummyHash: dummy}
+}
+
+// Login はメールアドレスとパスワードを照合し、トークンを発行する。
+func (s *service) Login(ctx context.Context, email, password string) (*admin_domain.Admin, TokenPair, error) {
+	if s.tokens == nil {
+		return nil, TokenPair{}, ErrTokenIssuerUnavailable
+	}
+	addr, err := admin_vo.NewEmail(email)
+	if err != nil {
+		return nil, TokenPair{}, ErrInvalidCredentials
+	}
+	admin, err := s.repo.FindByEmail(ctx, addr)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	if admin == nil {
+		s.dummyHash.Matches(password)
+		return nil, TokenPair{}, ErrInvalidCredentials
+	}
+	if !admin.Authenticate(password) {
+		return nil, TokenPair{}, ErrInvalidCredentials
+	}
+
+	admin.RecordLogin(common_vo.NowTimestamp())
+	if err := s.repo.Save(ctx, admin); err != nil {
+		return nil, TokenPair{}, err
+	}
+
+	pair, err := s.issuePair(admin)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	return admin, pair, nil
+}
+
+// Refresh はリフレッシュトークンを検証し、新しいトークンの組を発行する。
+// 発行後にアカウントが削除されている場合は認証エラーとする。
+func (s *service) Refresh(ctx context.Context, refreshToken string) (*admin_domain.Admin, TokenPair, error) {
+	if s.tokens == nil {
+		return nil, TokenPair{}, ErrTokenIssuerUnavailable
+	}
+	subject, err := s.tokens.VerifyRefreshToken(refreshToken)
+	if err != nil {
+		return nil, TokenPair{}, ErrInvalidCredentials
+	}
+	id, err := admin_vo.NewID(subject)
+	if err != nil {
+		return nil, TokenPair{}, ErrInvalidCredentials
+	}
+	admin, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	if admin == nil {
+		return nil, TokenPair{}, ErrInvalidCredentials
+	}
+
+	pair, err := s.issuePair(admin)
+	if err != nil {
+		return nil, TokenPair{}, err
+	}
+	return admin, pair, nil
+}
+
+// Bootstrap は owner が 1 人もいない場合に限り、指定アカウントを owner として用意する。
+// アカウントが既に存在すれば owner に昇格し、存在しなければ作成する。変更した場合は true を返す。
+func (s *service) Bootstrap(ctx context.Context, email, password string) (bool, error) {
+	owner, err := admin_vo.NewRole(admin_vo.RoleOwner)
+	if err != nil {
+		return false, err
+	}
+	count, err := s.repo.CountByRole(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	addr, err := admin_vo.NewEmail(email)
+	if err != nil {
+		return false, err
+	}
+	existing, err := s.repo.FindByEmail(ctx, addr)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		if err := existing.ChangeRole(owner, common_vo.NowTimestamp()); err != nil {
+			return false, err
+		}
+		return true, s.repo.Save(ctx, existing)
+	}
+
+	if _, err := s.Create(ctx, email, password, owner); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Create は管理者アカウントを新規作成する。
+func (s *service) Create(ctx context.Context, email, password string, role admin_vo.Role) (*admin_domain.Admin, error) {
+	addr, err := admin_vo.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.FindByEmail(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrEmailTaken
+	}
+	hash, err := admin_vo.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	id, err := admin_vo.NewID(primitive.NewObjectID().Hex())
+	if err != nil {
+		return nil, err
+	}
+	admin, err := admin_domain.NewAdmin(id, addr, hash, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, admin); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// List は管理者を一覧で返す。
+func (s *service) List(ctx context.Context) ([]*admin_domain.Admin, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// ChangeRole は管理者のロールを変更する。最後の owner を降格することはできない。
+func (s *service) ChangeRole(ctx context.Context, id admin_vo.ID, role admin_vo.Role) (*admin_domain.Admin, error) {
+	admin, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, ErrAdminNotFound
+	}
+	if admin.Role().Value() == admin_vo.RoleOwner && role.Value() != admin_vo.RoleOwner {
+		count, err := s.repo.CountByRole(ctx, admin.Role())
+		if err != nil {
+			return nil, err
+		}
+		if count <= 1 {
+			return nil, ErrLastOwner
+		}
+	}
+	if err := admin.ChangeRole(role, common_vo.NowTimestamp()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, admin); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (s *service) issuePair(admin *admin_domain.Admin) (TokenPair, error) {
+	subject := admin.ID().Value()
+	access, accessExp, err := s.tokens.IssueAccessToken(subject, admin.Role().Value())
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refresh, refreshExp, err := s.tokens.IssueRefreshToken(subject)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return TokenPair{
+		AccessToken:      access,
+		AccessExpiresAt:  accessExp,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: refreshExp,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"context"
+	"errors"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+
+	audit_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/audit"
+)
+
+// Service は監査ログに関するアプリケーションサービス。
+type Service interface {
+	Record(context.Context, *audit_domain.Entry) error
+	List(context.Context, audit_domain.Filter, common_vo.Pagination) ([]*audit_domain.Entry, int64, error)
+}
+
+type service struct {
+	repo audit_domain.Repo
+}
+
+// NewService は AuditService を生成する。
+func NewService(repo audit_domain.Repo) Service {
+	if repo == nil {
+		panic("audit usecase: repo is nil")
+	}
+	return &service{repo: repo}
+}
+
+// Record は監査ログを 1 件記録する。
+func (s *service) Record(ctx context.Context, entry *audit_domain.Entry) error {
+	if entry == nil {
+		return errors.New("audit usecase: entry is nil")
+	}
+	return s.repo.Save(ctx, entry)
+}
+
+// List は条件に合う監査ログをページング取得する。
+func (s *service) List(ctx context.Context, filter audit_domain.Filter, page common_vo.Pagination) ([]*audit_domain.Entry, int64, error) {
+	return s.repo.Find(ctx, filter, page)
+}
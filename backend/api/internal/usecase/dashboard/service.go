@@ -0,0 +1,209 @@
+// Package dashboard は管理画面のダッシュボードを組み立てるアプリケーションサービス。
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	dashboard_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/dashboard"
+)
+
+const (
+	// DefaultDayPeriods は日単位の推移で遡る日数（今日を含む）の既定値。
+	DefaultDayPeriods = 14
+	// MaxDayPeriods は日単位の推移で遡れる日数の上限。
+	MaxDayPeriods = 90
+	// DefaultWeekPeriods は週単位の推移で遡る週数（今週を含む）の既定値。
+	DefaultWeekPeriods = 12
+	// MaxWeekPeriods は週単位の推移で遡れる週数の上限。
+	MaxWeekPeriods = 52
+	// DefaultStaleMonths は最新のアンケートがこの月数より古い店舗を更新が止まっているとみなす既定値。
+	DefaultStaleMonths = 6
+	// MaxStaleMonths は StaleMonths に指定できる上限。
+	MaxStaleMonths = 60
+	// DefaultListLimit は店舗・都道府県の一覧に返す件数の既定値。
+	DefaultListLimit = 10
+	// MaxListLimit は店舗・都道府県の一覧に返す件数の上限。
+	MaxListLimit = 50
+)
+
+// ErrInvalidGranularity は未知の集計単位が指定された場合に返される。
+var ErrInvalidGranularity = errors.New("granularity は day / week のいずれかを指定してください")
+
+// ParseGranularity は文字列から集計単位を生成する。空の場合は day とする。
+func ParseGranularity(raw string) (dashboard_domain.Granularity, error) {
+	switch g := dashboard_domain.Granularity(strings.TrimSpace(raw)); g {
+	case "":
+		return dashboard_domain.GranularityDay, nil
+	case dashboard_domain.GranularityDay, dashboard_domain.GranularityWeek:
+		return g, nil
+	default:
+		return "", ErrInvalidGranularity
+	}
+}
+
+// Query はダッシュボードの集計条件を表す。0 の項目は既定値、上限を超える値は上限に丸める。
+type Query struct {
+	Granularity dashboard_domain.Granularity
+	Periods     int
+	StaleMonths int
+	Limit       int
+}
+
+// Dashboard は管理画面に表示する運用指標をまとめたもの。
+// 推移は Since から今日・今週までを件数 0 の期間も含めて古い順に並べる。
+// TopPrefectures は Since 以降の動きを集計する。
+type Dashboard struct {
+	Granularity      dashboard_domain.Granularity
+	Timezone         string
+	Since            time.Time
+	Submissions      []dashboard_domain.CountPoint
+	PublishedSurveys []dashboard_domain.CountPoint
+	NewStores        []dashboard_domain.CountPoint
+	Pending          dashboard_domain.PendingCounts
+
+	StoresWithoutSurveys StoreList
+	StaleMonths          int
+	StaleBefore          time.Time
+	StaleStores          StoreList
+	TopPrefectures       []dashboard_domain.PrefectureActivity
+}
+
+// StoreList は条件に該当する店舗の総数と、その先頭部分を表す。
+type StoreList struct {
+	Total int64
+	Items []dashboard_domain.StoreActivity
+}
+
+// Service はダッシュボードに関するアプリケーションサービス。
+type Service interface {
+	Get(context.Context, Query) (Dashboard, error)
+}
+
+type service struct {
+	repo     dashboard_domain.Repo
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewService は DashboardService を生成する。日・週の境界は location で判定し、nil の場合は UTC とする。
+func NewService(repo dashboard_domain.Repo, location *time.Location) Service {
+	if repo == nil {
+		panic("dashboard usecase: repo is nil")
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return &service{repo: repo, location: location, now: time.Now}
+}
+
+// Get は各コレクションを集計してダッシュボードを組み立てる。
+func (s *service) Get(ctx context.Context, q Query) (Dashboard, error) {
+	q = normalizeQuery(q)
+	now := s.now().In(s.location)
+	since := periodStart(now, q.Granularity).AddDate(0, 0, -(q.Periods-1)*periodDays(q.Granularity))
+	window := dashboard_domain.Window{Since: since, Granularity: q.Granularity, Timezone: s.location.String()}
+
+	result := Dashboard{
+		Granularity: q.Granularity,
+		Timezone:    window.Timezone,
+		Since:       since,
+		StaleMonths: q.StaleMonths,
+		StaleBefore: now.AddDate(0, -q.StaleMonths, 0),
+	}
+
+	submissions, err := s.repo.SubmissionCounts(ctx, window)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	result.Submissions = fillPeriods(submissions, since, q)
+
+	surveys, err := s.repo.PublishedSurveyCounts(ctx, window)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	result.PublishedSurveys = fillPeriods(surveys, since, q)
+
+	stores, err := s.repo.NewStoreCounts(ctx, window)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	result.NewStores = fillPeriods(stores, since, q)
+
+	if result.Pending, err = s.repo.PendingCounts(ctx); err != nil {
+		return Dashboard{}, err
+	}
+
+	items, total, err := s.repo.StoresWithoutSurveys(ctx, q.Limit)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	result.StoresWithoutSurveys = StoreList{Total: total, Items: items}
+
+	items, total, err = s.repo.StaleStores(ctx, result.StaleBefore, q.Limit)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	result.StaleStores = StoreList{Total: total, Items: items}
+
+	if result.TopPrefectures, err = s.repo.TopPrefectures(ctx, since, q.Limit); err != nil {
+		return Dashboard{}, err
+	}
+	return result, nil
+}
+
+func normalizeQuery(q Query) Query {
+	if q.Granularity == "" {
+		q.Granularity = dashboard_domain.GranularityDay
+	}
+	defaultPeriods, maxPeriods := DefaultDayPeriods, MaxDayPeriods
+	if q.Granularity == dashboard_domain.GranularityWeek {
+		defaultPeriods, maxPeriods = DefaultWeekPeriods, MaxWeekPeriods
+	}
+	q.Periods = clamp(q.Periods, defaultPeriods, maxPeriods)
+	q.StaleMonths = clamp(q.StaleMonths, DefaultStaleMonths, MaxStaleMonths)
+	q.Limit = clamp(q.Limit, DefaultListLimit, MaxListLimit)
+	return q
+}
+
+func clamp(v, fallback, max int) int {
+	if v <= 0 {
+		return fallback
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// periodStart は t を含む日・週（月曜始まり）の開始日時を t のタイムゾーンで返す。
+func periodStart(t time.Time, granularity dashboard_domain.Granularity) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if granularity == dashboard_domain.GranularityWeek {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	}
+	return start
+}
+
+func periodDays(granularity dashboard_domain.Granularity) int {
+	if granularity == dashboard_domain.GranularityWeek {
+		return 7
+	}
+	return 1
+}
+
+// fillPeriods は件数のある期間だけの集計結果を、since から q.Periods 期間分の連続した推移に揃える。
+func fillPeriods(points []dashboard_domain.CountPoint, since time.Time, q Query) []dashboard_domain.CountPoint {
+	counts := make(map[int64]int64, len(points))
+	for _, p := range points {
+		counts[p.Start.Unix()] = p.Count
+	}
+	filled := make([]dashboard_domain.CountPoint, 0, q.Periods)
+	for i := 0; i < q.Periods; i++ {
+		start := since.AddDate(0, 0, i*periodDays(q.Granularity))
+		filled = append(filled, dashboard_domain.CountPoint{Start: start, Count: counts[start.Unix()]})
+	}
+	return filled
+}
@@ -0,0 +1,291 @@
+// Package estimate は勤務者の属性から稼ぎの目安を推定するアプリケーションサービス。
+package estimate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	statistics_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/statistics"
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+const (
+	// DefaultNeighbors は推定に使う近傍のアンケート件数。
+	DefaultNeighbors = 15
+	// DefaultMinSampleSize は推定を返すのに必要な比較対象のアンケート件数の既定値。
+	DefaultMinSampleSize = 5
+	// DefaultRefreshInterval はモデルを作り直す間隔の既定値。
+	DefaultRefreshInterval = time.Hour
+
+	// workTypeWeight は勤務形態が異なるアンケートとの距離に加える重み。
+	// 年齢・スペックは範囲全体を 1 に正規化しているため、範囲の半分ほどの差として扱う。
+	workTypeWeight = 0.5
+)
+
+var (
+	// ErrStoreNotFound は指定した店舗が存在しない場合に返される。
+	ErrStoreNotFound = errors.New("店舗が見つかりません")
+	// ErrInsufficientData は比較できるアンケートが少なく推定できない場合に返される。
+	ErrInsufficientData = errors.New("推定に必要なアンケートが不足しています")
+)
+
+// Profile は推定したい勤務者の属性と、働く候補の店舗・地域を表す。
+// Age/SpecScore は必須で、それ以外は nil の場合に条件へ含めない。
+// StoreID を指定し Prefecture/Industry を省略した場合は店舗の値を使う。
+type Profile struct {
+	Age        survey_vo.Age
+	SpecScore  survey_vo.SpecScore
+	WorkType   *survey_vo.WorkType
+	Industry   *store_vo.Industry
+	Prefecture *store_vo.Prefecture
+	StoreID    *store_vo.ID
+}
+
+// Scope は推定の比較対象にしたアンケートの範囲を表す。
+type Scope string
+
+const (
+	ScopeStore              Scope = "store"
+	ScopePrefectureIndustry Scope = "prefectureIndustry"
+	ScopeIndustry           Scope = "industry"
+	ScopePrefecture         Scope = "prefecture"
+	ScopeAll                Scope = "all"
+)
+
+// Estimate は 1 日あたりの平均稼ぎ(万円)の推定結果を表す。
+// Low/High は属性の近いアンケートの稼ぎの第 1・第 3 四分位で、Expected は距離で重み付けした平均。
+// ScopeSurveys は Scope に含まれるアンケート件数、ComparableSurveys はそのうち推定に使った件数。
+type Estimate struct {
+	Scope             Scope
+	Low               float64
+	Expected          float64
+	High              float64
+	ComparableSurveys int
+	ScopeSurveys      int
+	ModelUpdatedAt    time.Time
+}
+
+// Service は稼ぎの推定に関するアプリケーションサービス。
+// Refresh は公開中のアンケートを読み直してモデルを作り直し、定期的に呼び出すことを想定する。
+type Service interface {
+	Estimate(context.Context, Profile) (Estimate, error)
+	Refresh(context.Context) error
+}
+
+// StoreReader は推定の対象店舗を取得する。該当がない場合は (nil, nil) を返す。
+type StoreReader interface {
+	FindByID(context.Context, store_vo.ID) (*store_domain.Store, error)
+}
+
+// SampleReader は推定に使う公開中のアンケートを取得する。
+type SampleReader interface {
+	EarningSamples(context.Context) ([]survey_domain.EarningSample, error)
+}
+
+// model は特定時点のアンケートから作った推定モデル。
+// 近傍探索は件数に比例するが、アンケートは数千件規模のため毎回全件を走査する。
+type model struct {
+	samples []survey_domain.EarningSample
+	builtAt time.Time
+}
+
+type service struct {
+	stores        StoreReader
+	samples       SampleReader
+	minSampleSize int
+	neighbors     int
+	now           func() time.Time
+
+	mu    sync.RWMutex
+	model *model
+}
+
+// NewService は EstimateService を生成する。minSampleSize が 0 以下の場合は DefaultMinSampleSize を使う。
+// モデルは最初の推定時、または Refresh の呼び出し時に作られる。
+func NewService(stores StoreReader, samples SampleReader, minSampleSize int) Service {
+	if stores == nil {
+		panic("estimate usecase: store reader is nil")
+	}
+	if samples == nil {
+		panic("estimate usecase: sample reader is nil")
+	}
+	if minSampleSize <= 0 {
+		minSampleSize = DefaultMinSampleSize
+	}
+	return &service{
+		stores:        stores,
+		samples:       samples,
+		minSampleSize: minSampleSize,
+		neighbors:     DefaultNeighbors,
+		now:           time.Now,
+	}
+}
+
+// Refresh は公開中のアンケートを読み直し、推定モデルを差し替える。
+// 読み込みに失敗した場合は直前のモデルを使い続ける。
+func (s *service) Refresh(ctx context.Context) error {
+	samples, err := s.samples.EarningSamples(ctx)
+	if err != nil {
+		return err
+	}
+	m := &model{samples: samples, builtAt: s.now()}
+	s.mu.Lock()
+	s.model = m
+	s.mu.Unlock()
+	return nil
+}
+
+// Estimate は profile に近い属性のアンケートから稼ぎの範囲を推定する。
+// 比較対象は店舗 → 都道府県と業種 → 業種 → 都道府県 → 全体の順に、minSampleSize 件以上ある最も狭い範囲を使う。
+func (s *service) Estimate(ctx context.Context, profile Profile) (Estimate, error) {
+	if profile.StoreID != nil {
+		store, err := s.stores.FindByID(ctx, *profile.StoreID)
+		if err != nil {
+			return Estimate{}, err
+		}
+		if store == nil {
+			return Estimate{}, ErrStoreNotFound
+		}
+		if profile.Prefecture == nil {
+			prefecture := store.Prefecture()
+			profile.Prefecture = &prefecture
+		}
+		if profile.Industry == nil {
+			industry := store.Industry()
+			profile.Industry = &industry
+		}
+	}
+
+	m, err := s.currentModel(ctx)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	for _, scope := range scopesFor(profile) {
+		pool := filterSamples(m.samples, profile, scope)
+		if len(pool) < s.minSampleSize {
+			continue
+		}
+		result := s.estimate(pool, profile)
+		result.Scope = scope
+		result.ModelUpdatedAt = m.builtAt
+		return result, nil
+	}
+	return Estimate{}, ErrInsufficientData
+}
+
+// currentModel は現在のモデルを返す。まだ作られていない場合はその場で作る。
+func (s *service) currentModel(ctx context.Context) (*model, error) {
+	s.mu.RLock()
+	m := s.model
+	s.mu.RUnlock()
+	if m != nil {
+		return m, nil
+	}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model, nil
+}
+
+// estimate は pool の中から属性の近いアンケートを選び、稼ぎの範囲を求める。
+func (s *service) estimate(pool []survey_domain.EarningSample, profile Profile) Estimate {
+	points := make([][]float64, 0, len(pool))
+	for _, sample := range pool {
+		points = append(points, features(sample.Age, sample.SpecScore, workTypeDistance(sample.WorkType, profile.WorkType)))
+	}
+	weights := []float64{1, 1, 0}
+	if profile.WorkType != nil {
+		weights[2] = workTypeWeight
+	}
+	neighbors := statistics_domain.Nearest(points, features(profile.Age, profile.SpecScore, 0), weights, s.neighbors)
+
+	earnings := make([]float64, 0, len(neighbors))
+	var weighted, totalWeight float64
+	for _, n := range neighbors {
+		earning := float64(pool[n.Index].AverageEarning.Value())
+		earnings = append(earnings, earning)
+		// 距離 0 でも発散しないよう 1 を足してから逆数を取る。
+		w := 1 / (1 + n.Distance)
+		weighted += earning * w
+		totalWeight += w
+	}
+	sorted := statistics_domain.Sorted(earnings)
+
+	return Estimate{
+		Low:               statistics_domain.Quantile(sorted, 0.25),
+		Expected:          weighted / totalWeight,
+		High:              statistics_domain.Quantile(sorted, 0.75),
+		ComparableSurveys: len(neighbors),
+		ScopeSurveys:      len(pool),
+	}
+}
+
+// features は年齢・スペックを入力可能な範囲で 0〜1 に正規化し、勤務形態の違いと並べた特徴量を返す。
+func features(age survey_vo.Age, spec survey_vo.SpecScore, workType float64) []float64 {
+	return []float64{
+		float64(age.Value()-survey_vo.MinAge) / float64(survey_vo.MaxAge-survey_vo.MinAge),
+		float64(spec.Value()-survey_vo.MinSpecScore) / float64(survey_vo.MaxSpecScore-survey_vo.MinSpecScore),
+		workType,
+	}
+}
+
+func workTypeDistance(sample survey_vo.WorkType, want *survey_vo.WorkType) float64 {
+	if want == nil || sample.Equals(*want) {
+		return 0
+	}
+	return 1
+}
+
+// scopesFor は profile で指定された条件から、狭い順に比較対象の範囲を並べる。
+func scopesFor(profile Profile) []Scope {
+	var scopes []Scope
+	if profile.StoreID != nil {
+		scopes = append(scopes, ScopeStore)
+	}
+	if profile.Prefecture != nil && profile.Industry != nil {
+		scopes = append(scopes, ScopePrefectureIndustry)
+	}
+	if profile.Industry != nil {
+		scopes = append(scopes, ScopeIndustry)
+	}
+	if profile.Prefecture != nil {
+		scopes = append(scopes, ScopePrefecture)
+	}
+	return append(scopes, ScopeAll)
+}
+
+func filterSamples(samples []survey_domain.EarningSample, profile Profile, scope Scope) []survey_domain.EarningSample {
+	if scope == ScopeAll {
+		return samples
+	}
+	var pool []survey_domain.EarningSample
+	for _, sample := range samples {
+		if matchesScope(sample, profile, scope) {
+			pool = append(pool, sample)
+		}
+	}
+	return pool
+}
+
+func matchesScope(sample survey_domain.EarningSample, profile Profile, scope Scope) bool {
+	switch scope {
+	case ScopeStore:
+		return sample.StoreID.Equals(*profile.StoreID)
+	case ScopePrefectureIndustry:
+		return sample.Prefecture.Equals(*profile.Prefecture) && sample.Industry.Equals(*profile.Industry)
+	case ScopeIndustry:
+		return sample.Industry.Equals(*profile.Industry)
+	case ScopePrefecture:
+		return sample.Prefecture.Equals(*profile.Prefecture)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,149 @@
+package statistics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+const (
+	// MinCompareStores は比較に必要な店舗数。
+	MinCompareStores = 2
+	// MaxCompareStores は一度に比較できる店舗数の上限。
+	MaxCompareStores = 5
+)
+
+// ErrInvalidCompareStores は比較する店舗数が範囲外の場合に返される。
+var ErrInvalidCompareStores = fmt.Errorf("比較する店舗は %d〜%d 件で指定してください", MinCompareStores, MaxCompareStores)
+
+// CompareMetric は比較で順位を付ける指標を表す。
+type CompareMetric string
+
+const (
+	CompareRating      CompareMetric = "rating"
+	CompareEarning     CompareMetric = "earning"
+	CompareWaitTime    CompareMetric = "waitTime"
+	CompareSurveyCount CompareMetric = "surveyCount"
+)
+
+// compareMetric は指標ごとの値の取り出し方と、値が小さいほど良いかを表す。
+type compareMetric struct {
+	metric        CompareMetric
+	value         func(store_vo.Stats) *float64
+	lowerIsBetter bool
+}
+
+var compareMetrics = []compareMetric{
+	{metric: CompareRating, value: func(s store_vo.Stats) *float64 { return s.AvgRating() }},
+	{metric: CompareEarning, value: func(s store_vo.Stats) *float64 { return s.AvgEarning() }},
+	{metric: CompareWaitTime, value: func(s store_vo.Stats) *float64 { return s.AvgWaitTime() }, lowerIsBetter: true},
+	{metric: CompareSurveyCount, value: func(s store_vo.Stats) *float64 {
+		if s.SurveyCount() == 0 {
+			return nil
+		}
+		v := float64(s.SurveyCount())
+		return &v
+	}},
+}
+
+// ComparisonEntry は比較対象の店舗 1 件分の結果を表す。Ranks は値のある指標のみを含む。
+type ComparisonEntry struct {
+	store_domain.Comparison
+	Ranks map[CompareMetric]MetricRank
+}
+
+// MetricRank は比較対象の中での順位を表す。
+// Rank は 1 が最も良く、同じ値は同順位とする。Score は最も良い値を 1、最も悪い値を 0 に正規化した値で、
+// 全店舗が同じ値の場合は 1 とする。
+type MetricRank struct {
+	Rank  int
+	Score float64
+}
+
+// Compare は指定した店舗を並べ、指標ごとに比較対象の中での順位を付ける。結果は指定した順に並ぶ。
+// 重複した ID は 1 件として扱い、存在しない店舗が含まれる場合は ErrStoreNotFound を返す。
+func (s *service) Compare(ctx context.Context, ids []store_vo.ID) ([]ComparisonEntry, error) {
+	ids = uniqueStoreIDs(ids)
+	if len(ids) < MinCompareStores || len(ids) > MaxCompareStores {
+		return nil, ErrInvalidCompareStores
+	}
+
+	comparisons, err := s.stores.FindForComparison(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]store_domain.Comparison, len(comparisons))
+	for _, c := range comparisons {
+		byID[c.Store.ID().Value()] = c
+	}
+
+	entries := make([]ComparisonEntry, 0, len(ids))
+	for _, id := range ids {
+		c, ok := byID[id.Value()]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrStoreNotFound, id.Value())
+		}
+		entries = append(entries, ComparisonEntry{Comparison: c, Ranks: map[CompareMetric]MetricRank{}})
+	}
+
+	for _, m := range compareMetrics {
+		rankMetric(entries, m)
+	}
+	return entries, nil
+}
+
+// rankMetric は指標の値を持つ店舗に順位と正規化スコアを付ける。
+func rankMetric(entries []ComparisonEntry, m compareMetric) {
+	type scored struct {
+		index int
+		value float64
+	}
+	var values []scored
+	for i, e := range entries {
+		if v := m.value(e.Stats); v != nil {
+			values = append(values, scored{index: i, value: *v})
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	better := func(a, b float64) bool {
+		if m.lowerIsBetter {
+			return a < b
+		}
+		return a > b
+	}
+	sort.SliceStable(values, func(i, j int) bool { return better(values[i].value, values[j].value) })
+
+	best, worst := values[0].value, values[len(values)-1].value
+	for i, v := range values {
+		rank := i + 1
+		if i > 0 && v.value == values[i-1].value {
+			rank = entries[values[i-1].index].Ranks[m.metric].Rank
+		}
+		score := 1.0
+		if best != worst {
+			// 小さいほど良い指標でも符号が揃うよう絶対値で割合を求める。
+			score = math.Abs(v.value-worst) / math.Abs(best-worst)
+		}
+		entries[v.index].Ranks[m.metric] = MetricRank{Rank: rank, Score: score}
+	}
+}
+
+func uniqueStoreIDs(ids []store_vo.ID) []store_vo.ID {
+	seen := make(map[string]struct{}, len(ids))
+	unique := make([]store_vo.ID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id.Value()]; ok {
+			continue
+		}
+		seen[id.Value()] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
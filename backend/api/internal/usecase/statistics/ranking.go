@@ -0,0 +1,322 @@
+package statistics
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	statistics_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/statistics"
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+)
+
+// Dimension はランキングをまとめる単位を表す。
+type Dimension string
+
+const (
+	DimensionPrefecture Dimension = "prefecture"
+	DimensionArea       Dimension = "area"
+	DimensionIndustry   Dimension = "industry"
+)
+
+// Metric はランキングの指標を表す。
+type Metric string
+
+const (
+	// MetricEarning は平均稼ぎの高い順。
+	MetricEarning Metric = "earning"
+	// MetricRating は総評の高い順。
+	MetricRating Metric = "rating"
+	// MetricWaitTime は待機時間の短い順。
+	MetricWaitTime Metric = "waitTime"
+)
+
+const (
+	// DefaultRankingLimit はグループごとに返す店舗数の既定値。
+	DefaultRankingLimit = 10
+	// MaxRankingLimit はグループごとに返す店舗数の上限。
+	MaxRankingLimit = 50
+	// rankingPriorWeight は事前平均（グループ全体の平均）を何件分のアンケートとして扱うか。
+	rankingPriorWeight = 5.0
+
+	// ConfidenceHigh は実データの重みが十分に大きいことを表す。
+	ConfidenceHigh = "high"
+	// ConfidenceMedium は事前平均と実データが同程度に効いていることを表す。
+	ConfidenceMedium = "medium"
+	// ConfidenceLow は件数が少なく、スコアの大半が事前平均によることを表す。
+	ConfidenceLow = "low"
+
+	confidenceHighWeight   = 0.75
+	confidenceMediumWeight = 0.5
+)
+
+var (
+	// ErrInvalidDimension は未知のランキング単位が指定された場合に返される。
+	ErrInvalidDimension = errors.New("groupBy は prefecture / area / industry のいずれかを指定してください")
+	// ErrInvalidMetric は未知のランキング指標が指定された場合に返される。
+	ErrInvalidMetric = errors.New("metric は earning / rating / waitTime のいずれかを指定してください")
+)
+
+// ParseDimension は文字列から Dimension を生成する。
+func ParseDimension(raw string) (Dimension, error) {
+	switch d := Dimension(strings.TrimSpace(raw)); d {
+	case DimensionPrefecture, DimensionArea, DimensionIndustry:
+		return d, nil
+	default:
+		return "", ErrInvalidDimension
+	}
+}
+
+// ParseMetric は文字列から Metric を生成する。空の場合は earning とする。
+func ParseMetric(raw string) (Metric, error) {
+	switch m := Metric(strings.TrimSpace(raw)); m {
+	case "":
+		return MetricEarning, nil
+	case MetricEarning, MetricRating, MetricWaitTime:
+		return m, nil
+	default:
+		return "", ErrInvalidMetric
+	}
+}
+
+// RankingQuery はランキングの取得条件を表す。Group を指定した場合はそのグループのみを返す。
+type RankingQuery struct {
+	GroupBy Dimension
+	Metric  Metric
+	Group   string
+	Limit   int
+}
+
+// RankingGroup は 1 グループ分のランキングを表す。
+// PriorMean はスコアの縮約先となるグループ全体の平均、SampleSize はグループ全体のアンケート件数。
+type RankingGroup struct {
+	Key        string
+	PriorMean  float64
+	SampleSize int
+	Entries    []RankingEntry
+}
+
+// RankingEntry はランキング内の 1 店舗を表す。
+// Average はアンケートの単純平均、Score はグループ平均へ縮約した推定値で、順位は Score で決める。
+// Confidence は Score のうち店舗自身のデータが占める重みから判定する。
+type RankingEntry struct {
+	Rank       int
+	Store      *store_domain.Store
+	SampleSize int
+	Average    float64
+	Score      float64
+	Weight     float64
+	Confidence string
+}
+
+// rankingKey はランキングの集計単位と指標の組み合わせ。
+type rankingKey struct {
+	groupBy Dimension
+	metric  Metric
+}
+
+// rankingSnapshot は特定時点の店舗から作ったすべてのランキング。
+// 各グループは MaxRankingLimit 件までの店舗を順位付きで持ち、リクエストごとに件数を切り詰めて返す。
+type rankingSnapshot struct {
+	groups map[rankingKey][]RankingGroup
+}
+
+// Rankings は店舗をグループごとにまとめ、指標のベイズ平均で順位付けする。
+// アンケートが 1 件もない店舗や、グループの値を持たない店舗（エリア未設定など）は対象外とする。
+// 結果は RefreshRankings で作ったスナップショットから返し、リクエストごとに店舗を読み直さない。
+func (s *service) Rankings(ctx context.Context, query RankingQuery) ([]RankingGroup, error) {
+	if _, err := ParseDimension(string(query.GroupBy)); err != nil {
+		return nil, err
+	}
+	if query.Metric == "" {
+		query.Metric = MetricEarning
+	}
+	if _, err := ParseMetric(string(query.Metric)); err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultRankingLimit
+	}
+	if limit > MaxRankingLimit {
+		limit = MaxRankingLimit
+	}
+	group := strings.TrimSpace(query.Group)
+
+	snapshot, err := s.currentRankings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := snapshot.groups[rankingKey{groupBy: query.GroupBy, metric: query.Metric}]
+	groups := make([]RankingGroup, 0, len(cached))
+	for _, g := range cached {
+		if group != "" && g.Key != group {
+			continue
+		}
+		// スナップショットを共有しているため、切り詰めた結果は複製して返す。
+		n := len(g.Entries)
+		if n > limit {
+			n = limit
+		}
+		g.Entries = append([]RankingEntry(nil), g.Entries[:n]...)
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// RefreshRankings は店舗を読み直し、すべての集計単位と指標のランキングを作り直す。
+// 読み込みに失敗した場合は直前のランキングを使い続ける。
+func (s *service) RefreshRankings(ctx context.Context) error {
+	stores, err := s.stores.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	snapshot := &rankingSnapshot{groups: map[rankingKey][]RankingGroup{}}
+	for _, dimension := range []Dimension{DimensionPrefecture, DimensionArea, DimensionIndustry} {
+		for _, metric := range []Metric{MetricEarning, MetricRating, MetricWaitTime} {
+			snapshot.groups[rankingKey{groupBy: dimension, metric: metric}] = buildRankings(stores, dimension, metric)
+		}
+	}
+	s.mu.Lock()
+	s.rankings = snapshot
+	s.mu.Unlock()
+	return nil
+}
+
+// currentRankings は現在のランキングを返す。まだ作られていない場合はその場で作る。
+func (s *service) currentRankings(ctx context.Context) (*rankingSnapshot, error) {
+	s.mu.RLock()
+	snapshot := s.rankings
+	s.mu.RUnlock()
+	if snapshot != nil {
+		return snapshot, nil
+	}
+	if err := s.RefreshRankings(ctx); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rankings, nil
+}
+
+// buildRankings は店舗を dimension ごとにまとめ、各グループの上位 MaxRankingLimit 件を順位付けする。
+// グループはアンケート件数の多い順に並べる。
+func buildRankings(stores []*store_domain.Store, dimension Dimension, metric Metric) []RankingGroup {
+	grouped := map[string][]*store_domain.Store{}
+	for _, store := range stores {
+		key, ok := dimensionKey(store, dimension)
+		if !ok {
+			continue
+		}
+		if _, ok := metricValue(store, metric); !ok {
+			continue
+		}
+		grouped[key] = append(grouped[key], store)
+	}
+
+	groups := make([]RankingGroup, 0, len(grouped))
+	for key, members := range grouped {
+		groups = append(groups, rankGroup(key, members, metric, MaxRankingLimit))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].SampleSize != groups[j].SampleSize {
+			return groups[i].SampleSize > groups[j].SampleSize
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+func rankGroup(key string, stores []*store_domain.Store, metric Metric, limit int) RankingGroup {
+	means := make([]float64, len(stores))
+	counts := make([]int, len(stores))
+	total := 0
+	for i, store := range stores {
+		means[i], _ = metricValue(store, metric)
+		counts[i] = store.Stats().SurveyCount()
+		total += counts[i]
+	}
+	prior := statistics_domain.WeightedMean(means, counts)
+
+	entries := make([]RankingEntry, 0, len(stores))
+	for i, store := range stores {
+		weight := statistics_domain.ShrinkageWeight(counts[i], rankingPriorWeight)
+		entries = append(entries, RankingEntry{
+			Store:      store,
+			SampleSize: counts[i],
+			Average:    means[i],
+			Score:      statistics_domain.BayesianAverage(means[i], counts[i], prior, rankingPriorWeight),
+			Weight:     weight,
+			Confidence: confidenceFromWeight(weight),
+		})
+	}
+
+	lowerIsBetter := metric == MetricWaitTime
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			if lowerIsBetter {
+				return entries[i].Score < entries[j].Score
+			}
+			return entries[i].Score > entries[j].Score
+		}
+		if entries[i].SampleSize != entries[j].SampleSize {
+			return entries[i].SampleSize > entries[j].SampleSize
+		}
+		return entries[i].Store.ID().Value() < entries[j].Store.ID().Value()
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	return RankingGroup{Key: key, PriorMean: prior, SampleSize: total, Entries: entries}
+}
+
+func dimensionKey(store *store_domain.Store, dimension Dimension) (string, bool) {
+	switch dimension {
+	case DimensionPrefecture:
+		return store.Prefecture().Value(), true
+	case DimensionIndustry:
+		return store.Industry().Value(), true
+	case DimensionArea:
+		if area := store.Area(); area != nil && strings.TrimSpace(area.Value()) != "" {
+			return area.Value(), true
+		}
+	}
+	return "", false
+}
+
+// metricValue は店舗の統計から指標の平均値を取り出す。アンケートがない場合は false を返す。
+func metricValue(store *store_domain.Store, metric Metric) (float64, bool) {
+	stats := store.Stats()
+	if stats.IsZero() {
+		return 0, false
+	}
+	var value *float64
+	switch metric {
+	case MetricEarning:
+		value = stats.AvgEarning()
+	case MetricRating:
+		value = stats.AvgRating()
+	case MetricWaitTime:
+		value = stats.AvgWaitTime()
+	}
+	if value == nil {
+		return 0, false
+	}
+	return *value, true
+}
+
+func confidenceFromWeight(weight float64) string {
+	switch {
+	case weight >= confidenceHighWeight:
+		return ConfidenceHigh
+	case weight >= confidenceMediumWeight:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
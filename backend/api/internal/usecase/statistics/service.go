@@ -0,0 +1,73 @@
+// Package statistics はアンケートを集計して統計情報を提供するアプリケーションサービス。
+package statistics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+const (
+	// DefaultMinSampleSize は分布を公開するのに必要なアンケート件数の既定値。
+	// 件数が少ないと個々の回答が推測できてしまうため、これを下回る場合は値を伏せる。
+	DefaultMinSampleSize = 5
+	// DefaultRankingRefreshInterval はランキングを作り直す間隔の既定値。
+	DefaultRankingRefreshInterval = 10 * time.Minute
+)
+
+// ErrStoreNotFound は指定した店舗が存在しない場合に返される。
+var ErrStoreNotFound = errors.New("店舗が見つかりません")
+
+// Service はアンケートの統計に関するアプリケーションサービス。
+// RefreshRankings は店舗を読み直してランキングを作り直し、定期的に呼び出すことを想定する。
+type Service interface {
+	StoreDistributions(context.Context, store_vo.ID) (StoreDistributions, error)
+	Rankings(context.Context, RankingQuery) ([]RankingGroup, error)
+	RefreshRankings(context.Context) error
+	StoreTrend(context.Context, store_vo.ID, TrendRange) (Trend, error)
+	ScopeTrend(context.Context, TrendScope, TrendRange) (Trend, error)
+	Compare(context.Context, []store_vo.ID) ([]ComparisonEntry, error)
+}
+
+// StoreReader は集計対象の店舗を取得する。FindAll は論理削除されていない店舗をすべて返す。
+type StoreReader interface {
+	FindByID(context.Context, store_vo.ID) (*store_domain.Store, error)
+	FindAll(context.Context) ([]*store_domain.Store, error)
+	FindForComparison(context.Context, []store_vo.ID) ([]store_domain.Comparison, error)
+}
+
+// SurveyReader は集計対象のアンケートを取得する。公開中のアンケートのみを返す実装を想定する。
+type SurveyReader interface {
+	FindByStore(context.Context, store_vo.ID, common_vo.SortKey, common_vo.Pagination) ([]*survey_domain.Survey, int64, error)
+	MonthlyTrends(context.Context, survey_domain.TrendFilter) ([]survey_domain.PeriodAggregate, error)
+}
+
+type service struct {
+	stores        StoreReader
+	surveys       SurveyReader
+	minSampleSize int
+
+	mu       sync.RWMutex
+	rankings *rankingSnapshot
+}
+
+// NewService は StatisticsService を生成する。minSampleSize が 0 以下の場合は DefaultMinSampleSize を使う。
+// ランキングは最初の取得時、または RefreshRankings の呼び出し時に作られる。
+func NewService(stores StoreReader, surveys SurveyReader, minSampleSize int) Service {
+	if stores == nil {
+		panic("statistics usecase: store reader is nil")
+	}
+	if surveys == nil {
+		panic("statistics usecase: survey reader is nil")
+	}
+	if minSampleSize <= 0 {
+		minSampleSize = DefaultMinSampleSize
+	}
+	return &service{stores: stores, surveys: surveys, minSampleSize: minSampleSize}
+}
@@ -0,0 +1,136 @@
+package statistics
+
+import (
+	"context"
+
+	statistics_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/statistics"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// Field は分布を求めるアンケートの項目を表す。
+type Field string
+
+const (
+	FieldAge            Field = "age"
+	FieldSpecScore      Field = "specScore"
+	FieldWaitTimeHours  Field = "waitTimeHours"
+	FieldAverageEarning Field = "averageEarning"
+	FieldRating         Field = "rating"
+)
+
+// fieldDefinition は項目ごとの値の取り出し方とヒストグラムの区切りを表す。
+type fieldDefinition struct {
+	field Field
+	value func(*survey_domain.Survey) float64
+	edges []float64
+}
+
+// distributionFields は分布を求める項目。区切りは各 VO の入力範囲に合わせている。
+var distributionFields = []fieldDefinition{
+	{
+		field: FieldAge,
+		value: func(s *survey_domain.Survey) float64 { return float64(s.Age().Value()) },
+		edges: []float64{survey_vo.MinAge, 20, 25, 30, 35, 40, 45, 50, survey_vo.MaxAge},
+	},
+	{
+		field: FieldSpecScore,
+		value: func(s *survey_domain.Survey) float64 { return float64(s.SpecScore().Value()) },
+		edges: statistics_domain.LinearEdges(survey_vo.MinSpecScore, survey_vo.MaxSpecScore, 10),
+	},
+	{
+		field: FieldWaitTimeHours,
+		value: func(s *survey_domain.Survey) float64 { return float64(s.WaitTime().Value()) },
+		edges: []float64{survey_vo.MinWaitTimeHours, 2, 4, 6, 8, 12, survey_vo.MaxWaitTimeHours},
+	},
+	{
+		field: FieldAverageEarning,
+		value: func(s *survey_domain.Survey) float64 { return float64(s.AverageEarning().Value()) },
+		edges: statistics_domain.LinearEdges(survey_vo.MinAverageEarning, survey_vo.MaxAverageEarning, 2),
+	},
+	{
+		field: FieldRating,
+		value: func(s *survey_domain.Survey) float64 { return s.Rating().Value() },
+		edges: statistics_domain.LinearEdges(survey_vo.MinRating, survey_vo.MaxRating, 1),
+	},
+}
+
+// StoreDistributions は店舗のアンケートから求めた項目ごとの分布を表す。
+// All は全アンケート、ByWorkType は勤務形態（在籍/出稼ぎ）ごとの集計。
+type StoreDistributions struct {
+	StoreID       store_vo.ID
+	MinSampleSize int
+	All           DistributionGroup
+	ByWorkType    map[string]DistributionGroup
+}
+
+// DistributionGroup は 1 つの集計単位の分布を表す。
+// SampleSize が最小件数に満たない場合は Withheld を true とし、Fields は空にする。
+type DistributionGroup struct {
+	SampleSize int
+	Withheld   bool
+	Fields     map[Field]statistics_domain.Distribution
+}
+
+// StoreDistributions は店舗の公開中のアンケートから項目ごとの分布を求める。
+// 外れ値の指摘が未確認のアンケートは店舗の統計と同じく分布に含めない。
+func (s *service) StoreDistributions(ctx context.Context, storeID store_vo.ID) (StoreDistributions, error) {
+	store, err := s.stores.FindByID(ctx, storeID)
+	if err != nil {
+		return StoreDistributions{}, err
+	}
+	if store == nil {
+		return StoreDistributions{}, ErrStoreNotFound
+	}
+
+	published, _, err := s.surveys.FindByStore(ctx, storeID, common_vo.SortKey{}, common_vo.Pagination{})
+	if err != nil {
+		return StoreDistributions{}, err
+	}
+	surveys := make([]*survey_domain.Survey, 0, len(published))
+	for _, survey := range published {
+		if !survey.HasPendingAnomalies() {
+			surveys = append(surveys, survey)
+		}
+	}
+
+	byWorkType := map[string][]*survey_domain.Survey{
+		survey_vo.WorkTypeLocal:   nil,
+		survey_vo.WorkTypeVisitor: nil,
+	}
+	for _, survey := range surveys {
+		workType := survey.WorkType().Value()
+		byWorkType[workType] = append(byWorkType[workType], survey)
+	}
+
+	result := StoreDistributions{
+		StoreID:       storeID,
+		MinSampleSize: s.minSampleSize,
+		All:           s.describeGroup(surveys),
+		ByWorkType:    make(map[string]DistributionGroup, len(byWorkType)),
+	}
+	for workType, group := range byWorkType {
+		result.ByWorkType[workType] = s.describeGroup(group)
+	}
+	return result, nil
+}
+
+func (s *service) describeGroup(surveys []*survey_domain.Survey) DistributionGroup {
+	group := DistributionGroup{SampleSize: len(surveys)}
+	if len(surveys) < s.minSampleSize {
+		group.Withheld = true
+		return group
+	}
+
+	group.Fields = make(map[Field]statistics_domain.Distribution, len(distributionFields))
+	values := make([]float64, len(surveys))
+	for _, def := range distributionFields {
+		for i, survey := range surveys {
+			values[i] = def.value(survey)
+		}
+		group.Fields[def.field] = statistics_domain.Describe(values, def.edges)
+	}
+	return group
+}
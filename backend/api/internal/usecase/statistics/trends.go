@@ -0,0 +1,222 @@
+package statistics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	statistics_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/statistics"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+)
+
+// Granularity は推移をまとめる期間の単位を表す。
+type Granularity string
+
+const (
+	GranularityMonth   Granularity = "month"
+	GranularityQuarter Granularity = "quarter"
+)
+
+const (
+	// DefaultTrendMonths は期間の開始が指定されなかった場合に遡る月数（終了月を含む）。
+	DefaultTrendMonths = 12
+	// MaxTrendMonths は 1 回に集計できる期間の上限（月数）。
+	MaxTrendMonths = 120
+)
+
+var (
+	// ErrInvalidGranularity は未知の期間単位が指定された場合に返される。
+	ErrInvalidGranularity = errors.New("granularity は month / quarter のいずれかを指定してください")
+	// ErrInvalidTrendRange は期間の開始が終了より後、または期間が長すぎる場合に返される。
+	ErrInvalidTrendRange = fmt.Errorf("期間は開始が終了以前で、%d か月以内で指定してください", MaxTrendMonths)
+	// ErrInvalidTrendScope は推移の集計対象が絞り込まれていない場合に返される。
+	ErrInvalidTrendScope = errors.New("area、または prefecture と industry の組み合わせを指定してください")
+)
+
+// ParseGranularity は文字列から Granularity を生成する。空の場合は month とする。
+func ParseGranularity(raw string) (Granularity, error) {
+	switch g := Granularity(strings.TrimSpace(raw)); g {
+	case "":
+		return GranularityMonth, nil
+	case GranularityMonth, GranularityQuarter:
+		return g, nil
+	default:
+		return "", ErrInvalidGranularity
+	}
+}
+
+// TrendRange は推移を求める稼働時期の範囲と期間の単位を表す。
+// To が未指定の場合は今月、From が未指定の場合は To から DefaultTrendMonths か月分を対象にする。
+type TrendRange struct {
+	From        survey_vo.VisitedPeriod
+	To          survey_vo.VisitedPeriod
+	Granularity Granularity
+}
+
+// TrendScope はエリア、または都道府県と業種の組み合わせで推移の集計対象を表す。
+// Area を指定した場合は Prefecture/Industry を追加の絞り込みとして扱う。
+type TrendScope struct {
+	Prefecture *store_vo.Prefecture
+	Area       *store_vo.Area
+	Industry   *store_vo.Industry
+}
+
+// Trend は稼働時期ごとの平均値の推移を表す。Points はアンケートのない期間も含めて古い順に並ぶ。
+type Trend struct {
+	Granularity   Granularity
+	From          survey_vo.VisitedPeriod
+	To            survey_vo.VisitedPeriod
+	MinSampleSize int
+	Points        []TrendPoint
+}
+
+// TrendPoint は 1 期間分の集計結果を表す。Period は "2024-01" または "2024-Q1" の形式で、
+// Start は期間のうち範囲に含まれる最初の月。
+// SampleSize が最小件数に満たない場合は Withheld を true とし、平均値はゼロ値にする。
+type TrendPoint struct {
+	Period          string
+	Start           survey_vo.VisitedPeriod
+	SampleSize      int
+	Withheld        bool
+	AverageEarning  float64
+	AverageRating   float64
+	AverageWaitTime float64
+}
+
+// StoreTrend は店舗の公開中のアンケートから稼働時期ごとの推移を求める。
+func (s *service) StoreTrend(ctx context.Context, storeID store_vo.ID, rng TrendRange) (Trend, error) {
+	store, err := s.stores.FindByID(ctx, storeID)
+	if err != nil {
+		return Trend{}, err
+	}
+	if store == nil {
+		return Trend{}, ErrStoreNotFound
+	}
+	id := storeID
+	return s.trend(ctx, survey_domain.TrendFilter{StoreID: &id}, rng)
+}
+
+// ScopeTrend はエリア、または都道府県と業種で絞り込んだ公開中のアンケートから稼働時期ごとの推移を求める。
+func (s *service) ScopeTrend(ctx context.Context, scope TrendScope, rng TrendRange) (Trend, error) {
+	if scope.Area == nil && (scope.Prefecture == nil || scope.Industry == nil) {
+		return Trend{}, ErrInvalidTrendScope
+	}
+	return s.trend(ctx, survey_domain.TrendFilter{
+		Prefecture: scope.Prefecture,
+		Area:       scope.Area,
+		Industry:   scope.Industry,
+	}, rng)
+}
+
+func (s *service) trend(ctx context.Context, filter survey_domain.TrendFilter, rng TrendRange) (Trend, error) {
+	rng, err := normalizeTrendRange(rng, time.Now())
+	if err != nil {
+		return Trend{}, err
+	}
+	filter.From = rng.From
+	filter.To = rng.To
+
+	months, err := s.surveys.MonthlyTrends(ctx, filter)
+	if err != nil {
+		return Trend{}, err
+	}
+	byMonth := make(map[string]survey_domain.PeriodAggregate, len(months))
+	for _, m := range months {
+		byMonth[periodKey(m.Period)] = m
+	}
+
+	result := Trend{
+		Granularity:   rng.Granularity,
+		From:          rng.From,
+		To:            rng.To,
+		MinSampleSize: s.minSampleSize,
+	}
+	var current *trendBucket
+	for t := rng.From.Value(); !t.After(rng.To.Value()); t = t.AddDate(0, 1, 0) {
+		label := trendLabel(t, rng.Granularity)
+		if current == nil || current.label != label {
+			if current != nil {
+				result.Points = append(result.Points, s.trendPoint(current))
+			}
+			current = &trendBucket{label: label, start: t}
+		}
+		if m, ok := byMonth[t.Format("2006-01")]; ok {
+			current.months = append(current.months, m)
+		}
+	}
+	if current != nil {
+		result.Points = append(result.Points, s.trendPoint(current))
+	}
+	return result, nil
+}
+
+// trendBucket は 1 期間にまとめる月ごとの集計結果。
+type trendBucket struct {
+	label  string
+	start  time.Time
+	months []survey_domain.PeriodAggregate
+}
+
+// trendPoint は月ごとの平均値を件数で重み付けして 1 期間分にまとめる。
+func (s *service) trendPoint(b *trendBucket) TrendPoint {
+	start, _ := survey_vo.NewVisitedPeriod(b.start.Format("2006-01"))
+	point := TrendPoint{Period: b.label, Start: start}
+
+	counts := make([]int, len(b.months))
+	earnings := make([]float64, len(b.months))
+	ratings := make([]float64, len(b.months))
+	waits := make([]float64, len(b.months))
+	for i, m := range b.months {
+		counts[i] = m.SurveyCount
+		earnings[i] = m.AvgEarning
+		ratings[i] = m.AvgRating
+		waits[i] = m.AvgWaitTime
+		point.SampleSize += m.SurveyCount
+	}
+	if point.SampleSize < s.minSampleSize {
+		point.Withheld = true
+		return point
+	}
+	point.AverageEarning = statistics_domain.WeightedMean(earnings, counts)
+	point.AverageRating = statistics_domain.WeightedMean(ratings, counts)
+	point.AverageWaitTime = statistics_domain.WeightedMean(waits, counts)
+	return point
+}
+
+// normalizeTrendRange は未指定の項目を既定値で補い、範囲を検証する。
+func normalizeTrendRange(rng TrendRange, now time.Time) (TrendRange, error) {
+	if rng.Granularity == "" {
+		rng.Granularity = GranularityMonth
+	}
+	if rng.To.IsZero() {
+		rng.To, _ = survey_vo.NewVisitedPeriod(now.Format("2006-01"))
+	}
+	if rng.From.IsZero() {
+		rng.From, _ = survey_vo.NewVisitedPeriod(rng.To.Value().AddDate(0, 1-DefaultTrendMonths, 0).Format("2006-01"))
+	}
+
+	months := monthsBetween(rng.From.Value(), rng.To.Value()) + 1
+	if months <= 0 || months > MaxTrendMonths {
+		return TrendRange{}, ErrInvalidTrendRange
+	}
+	return rng, nil
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+func trendLabel(t time.Time, granularity Granularity) string {
+	if granularity == GranularityQuarter {
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	}
+	return t.Format("2006-01")
+}
+
+func periodKey(p survey_vo.VisitedPeriod) string {
+	return p.Value().Format("2006-01")
+}
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+// DeletePolicy は店舗削除時に紐づくアンケートをどう扱うかを表す。
+type DeletePolicy string
+
+const (
+	// DeletePolicyBlock はアンケートが残っている店舗の削除を拒否する。
+	// ゴミ箱にあるアンケートも数え、店舗だけが削除された状態でアンケートを個別に復元できないようにする。
+	DeletePolicyBlock DeletePolicy = "block"
+	// DeletePolicyCascade は店舗と同時にアンケートも論理削除する。店舗を復元するとアンケートも復元される。
+	DeletePolicyCascade DeletePolicy = "cascade"
+	// DeletePolicyReassign はアンケートを別の店舗へ付け替えてから店舗を削除する。
+	DeletePolicyReassign DeletePolicy = "reassign"
+)
+
+var (
+	// ErrInvalidDeletePolicy は未知の削除ポリシーが指定された場合に返される。
+	ErrInvalidDeletePolicy = errors.New("削除ポリシーは block / cascade / reassign のいずれかを指定してください")
+	// ErrStoreHasSurveys は block ポリシーでアンケートが残っている店舗を削除しようとした場合に返される。
+	ErrStoreHasSurveys = errors.New("この店舗にはアンケート（ゴミ箱にあるものを含む）が紐づいているため削除できません")
+	// ErrReassignTargetRequired は reassign ポリシーで付け替え先が指定されていない場合に返される。
+	ErrReassignTargetRequired = errors.New("付け替え先の店舗を指定してください")
+	// ErrInvalidReassignTarget は付け替え先が削除対象と同じ、または存在しない店舗の場合に返される。
+	ErrInvalidReassignTarget = errors.New("付け替え先の店舗が不正です")
+)
+
+// ParseDeletePolicy は文字列から DeletePolicy を生成する。
+func ParseDeletePolicy(raw string) (DeletePolicy, error) {
+	switch policy := DeletePolicy(strings.ToLower(strings.TrimSpace(raw))); policy {
+	case DeletePolicyBlock, DeletePolicyCascade, DeletePolicyReassign:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidDeletePolicy, raw)
+	}
+}
+
+// DeleteOptions は店舗削除時の指定。Policy が空の場合はサービスの既定ポリシーを使う。
+// ReassignTo は reassign ポリシーの場合のみ必須。
+type DeleteOptions struct {
+	Policy     DeletePolicy
+	ReassignTo *store_vo.ID
+}
+
+// DeleteResult は店舗削除の結果を表す。
+// AffectedSurveys は論理削除または付け替えたアンケートの件数。block ポリシーでは常に 0 となる。
+type DeleteResult struct {
+	Store           *store_domain.Store
+	Policy          DeletePolicy
+	AffectedSurveys int64
+	ReassignedTo    *store_domain.Store
+}
+
+// Delete は店舗を論理削除し、削除ポリシーに従って紐づくアンケートを処理する。
+// 店舗とアンケートの更新は 1 つのトランザクションで行い、途中で失敗した場合はどちらも反映しない。
+func (s *service) Delete(ctx context.Context, id store_vo.ID, opts DeleteOptions) (DeleteResult, error) {
+	policy := opts.Policy
+	if policy == "" {
+		policy = s.deletePolicy
+	}
+	if policy == DeletePolicyReassign {
+		if opts.ReassignTo == nil {
+			return DeleteResult{}, ErrReassignTargetRequired
+		}
+		if opts.ReassignTo.Equals(id) {
+			return DeleteResult{}, ErrInvalidReassignTarget
+		}
+	}
+
+	var result DeleteResult
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// トランザクションが再試行された場合に前回の結果が残らないよう毎回作り直す。
+		result = DeleteResult{Policy: policy}
+
+		store, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if store == nil {
+			return ErrStoreNotFound
+		}
+		now := common_vo.NowTimestamp()
+
+		switch policy {
+		case DeletePolicyBlock:
+			count, err := s.surveys.CountByStore(ctx, id)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w (%d 件)", ErrStoreHasSurveys, count)
+			}
+		case DeletePolicyCascade:
+			result.AffectedSurveys, err = s.surveys.SoftDeleteByStore(ctx, id, now)
+			if err != nil {
+				return err
+			}
+		case DeletePolicyReassign:
+			target, err := s.repo.FindByID(ctx, *opts.ReassignTo)
+			if err != nil {
+				return err
+			}
+			if target == nil {
+				return ErrInvalidReassignTarget
+			}
+			result.AffectedSurveys, err = s.surveys.ReassignStore(ctx, id, newStoreSnapshot(target), now)
+			if err != nil {
+				return err
+			}
+			result.ReassignedTo = target
+		default:
+			return ErrInvalidDeletePolicy
+		}
+
+		if err := store.MarkDeleted(now); err != nil {
+			return err
+		}
+		if err := s.repo.Save(ctx, store); err != nil {
+			return err
+		}
+		if result.AffectedSurveys > 0 {
+			if err := s.applyRefreshedStats(ctx, store); err != nil {
+				return err
+			}
+			if result.ReassignedTo != nil {
+				if err := s.applyRefreshedStats(ctx, result.ReassignedTo); err != nil {
+					return err
+				}
+			}
+		}
+		result.Store = store
+		return nil
+	})
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	return result, nil
+}
+
+// newStoreSnapshot はアンケートに複製する店舗情報を組み立てる。
+func newStoreSnapshot(store *store_domain.Store) survey_domain.StoreSnapshot {
+	return survey_domain.StoreSnapshot{
+		ID:         store.ID(),
+		Name:       store.Name(),
+		Branch:     store.BranchName(),
+		Prefecture: store.Prefecture(),
+		Area:       store.Area(),
+		Industry:   store.Industry(),
+		Genre:      store.Genre(),
+	}
+}
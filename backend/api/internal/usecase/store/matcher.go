@@ -0,0 +1,238 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	"github.com/sngm3741/makoto-club-services/api/internal/domain/textnorm"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+const (
+	// 照合スコアの重み。クエリに含まれない項目は分母から除外する。
+	matchWeightName       = 0.6
+	matchWeightBranch     = 0.15
+	matchWeightPrefecture = 0.15
+	matchWeightIndustry   = 0.1
+
+	// matchMinScore 未満の候補は返さない。
+	matchMinScore = 0.3
+	// 片方の店舗名がもう片方を含む場合の類似度の下限。「○○」と「○○ 渋谷店」のような表記揺れを拾う。
+	matchContainsScore = 0.8
+
+	// DefaultMatchLimit は候補数の指定がない場合に返す件数。
+	DefaultMatchLimit = 5
+	// MaxMatchLimit は返す候補数の上限。これより大きい件数を指定した場合は上限に丸める。
+	MaxMatchLimit = 50
+
+	// matchGramLength は候補の絞り込みに使う店舗名の n-gram の文字数。採点の Dice 係数と揃えてバイグラムとする。
+	matchGramLength = 2
+	// matchCandidateLimit は採点する候補の上限。
+	matchCandidateLimit = 1000
+
+	// ConfidenceHigh はほぼ同一店舗と判断できる一致度。
+	ConfidenceHigh = "high"
+	// ConfidenceMedium は確認が必要な一致度。
+	ConfidenceMedium = "medium"
+	// ConfidenceLow は候補として提示するに留める一致度。
+	ConfidenceLow = "low"
+
+	confidenceHighScore   = 0.85
+	confidenceMediumScore = 0.6
+)
+
+// MatchQuery は一般投稿などの自由入力から店舗を照合する条件を表す。
+type MatchQuery struct {
+	Name       string
+	Branch     string
+	Prefecture string
+	Industry   string
+}
+
+// Match は照合結果の候補店舗を表す。Score は 0〜1 で、大きいほど一致している。
+type Match struct {
+	Store      *store_domain.Store
+	Score      float64
+	Confidence string
+}
+
+// Match は店舗名・支店名・都道府県・業種の一致度で候補店舗を採点し、スコアの高い順に limit 件返す。
+// 店舗名が空の場合は候補なしとする。limit は MaxMatchLimit を上限とする。
+// 採点するのは、正規化した店舗名のバイグラムのいずれかを含む店舗と、都道府県・業種が一致する店舗に限る。
+// 先頭の文字で絞り込むと「ソープ○○」と「○○」のように前に語が付いた表記を拾えないため、名前のどこが一致してもよい。
+func (s *service) Match(ctx context.Context, query MatchQuery, limit int) ([]Match, error) {
+	name := normalizeName(query.Name)
+	if name == "" {
+		return []Match{}, nil
+	}
+	if limit <= 0 {
+		limit = DefaultMatchLimit
+	}
+	if limit > MaxMatchLimit {
+		limit = MaxMatchLimit
+	}
+
+	stores, err := s.repo.FindMatchCandidates(ctx, candidateFilter(query, name), matchCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := normalizeBranch(query.Branch)
+	pref := normalizePrefecture(query.Prefecture)
+	industry := normalizeName(query.Industry)
+
+	matches := make([]Match, 0, limit)
+	for _, store := range stores {
+		score := scoreStore(store, name, branch, pref, industry)
+		if score < matchMinScore {
+			continue
+		}
+		matches = append(matches, Match{Store: store, Score: score, Confidence: confidenceOf(score)})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// candidateFilter は照合の候補を絞り込む条件を組み立てる。
+// 都道府県・業種は登録できる値として解釈できた場合のみ条件に使う。
+func candidateFilter(query MatchQuery, name string) store_domain.CandidateFilter {
+	filter := store_domain.CandidateFilter{NameGrams: nameGrams(name)}
+	if pref, err := store_vo.NewPrefecture(query.Prefecture); err == nil {
+		filter.Prefecture = &pref
+	}
+	if industry, err := store_vo.NewIndustry(query.Industry); err == nil {
+		filter.Industry = &industry
+	}
+	return filter
+}
+
+// nameGrams は正規化済みの店舗名を matchGramLength 文字ずつずらした n-gram を重複なく返す。
+// 名前が matchGramLength 文字に満たない場合は名前全体を 1 つの n-gram とする。
+func nameGrams(name string) []string {
+	runes := []rune(name)
+	if len(runes) <= matchGramLength {
+		return []string{name}
+	}
+	seen := make(map[string]struct{}, len(runes))
+	grams := make([]string, 0, len(runes)-matchGramLength+1)
+	for i := 0; i+matchGramLength <= len(runes); i++ {
+		gram := string(runes[i : i+matchGramLength])
+		if _, ok := seen[gram]; ok {
+			continue
+		}
+		seen[gram] = struct{}{}
+		grams = append(grams, gram)
+	}
+	return grams
+}
+
+// scoreStore は正規化済みのクエリと店舗を比較し、重み付き平均のスコアを返す。
+func scoreStore(store *store_domain.Store, name, branch, pref, industry string) float64 {
+	total := matchWeightName
+	score := matchWeightName * nameSimilarity(name, normalizeName(store.Name().Value()))
+
+	if branch != "" {
+		total += matchWeightBranch
+		if b := store.BranchName(); b != nil {
+			score += matchWeightBranch * nameSimilarity(branch, normalizeBranch(b.Value()))
+		}
+	}
+	if pref != "" {
+		total += matchWeightPrefecture
+		if pref == normalizePrefecture(store.Prefecture().Value()) {
+			score += matchWeightPrefecture
+		}
+	}
+	if industry != "" {
+		total += matchWeightIndustry
+		if industry == normalizeName(store.Industry().Value()) {
+			score += matchWeightIndustry
+		}
+	}
+	return score / total
+}
+
+func confidenceOf(score float64) string {
+	switch {
+	case score >= confidenceHighScore:
+		return ConfidenceHigh
+	case score >= confidenceMediumScore:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+// normalizeName は全角/半角・平仮名/片仮名の違いを揃え、長音記号・空白・記号を取り除く。
+func normalizeName(value string) string {
+	return textnorm.Normalize(value)
+}
+
+// normalizeBranch は支店名を正規化し、末尾の「店」を取り除く。「渋谷店」と「渋谷」を同一視するため。
+func normalizeBranch(value string) string {
+	value = normalizeName(value)
+	if trimmed := strings.TrimSuffix(value, "店"); trimmed != "" {
+		return trimmed
+	}
+	return value
+}
+
+// normalizePrefecture は都道府県名を正規化し、末尾の「都」「府」「県」を取り除く。
+// 北海道はそのまま残す。
+func normalizePrefecture(value string) string {
+	value = normalizeName(value)
+	for _, suffix := range []string{"都", "府", "県"} {
+		if trimmed := strings.TrimSuffix(value, suffix); trimmed != value && trimmed != "" {
+			return trimmed
+		}
+	}
+	return value
+}
+
+// nameSimilarity は正規化済みの 2 つの名前の類似度を 0〜1 で返す。
+func nameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+	score := diceCoefficient(a, b)
+	if (strings.Contains(a, b) || strings.Contains(b, a)) && score < matchContainsScore {
+		score = matchContainsScore
+	}
+	return score
+}
+
+// diceCoefficient は文字バイグラムの Dice 係数を返す。1 文字の場合は一致/不一致のみで判定する。
+func diceCoefficient(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 2 || len(rb) < 2 {
+		if a == b {
+			return 1
+		}
+		return 0
+	}
+
+	grams := make(map[string]int, len(ra)-1)
+	for i := 0; i < len(ra)-1; i++ {
+		grams[string(ra[i:i+2])]++
+	}
+	overlap := 0
+	for i := 0; i < len(rb)-1; i++ {
+		g := string(rb[i : i+2])
+		if grams[g] > 0 {
+			grams[g]--
+			overlap++
+		}
+	}
+	return 2 * float64(overlap) / float64(len(ra)-1+len(rb)-1)
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+)
+
+// ReconcileResult は店舗情報の複製を修復した結果を表す。
+// dry run の場合、Surveys は修復対象となるアンケートの件数を表す。
+type ReconcileResult struct {
+	Stores        int
+	DriftedStores int
+	Surveys       int64
+}
+
+// ReconcileSurveys は全店舗について、アンケートに複製された店舗情報を現在の店舗情報に揃える。
+// 店舗更新時の反映が導入される前に生じたずれを修復するための運用コマンド向け。
+// ゴミ箱の店舗も復元時に古い情報が表示されないよう対象にする。dryRun の場合は件数の集計のみ行う。
+func (s *service) ReconcileSurveys(ctx context.Context, dryRun bool) (ReconcileResult, error) {
+	active, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+	deleted, _, err := s.repo.FindDeleted(ctx, common_vo.Pagination{})
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+
+	var result ReconcileResult
+	now := common_vo.NowTimestamp()
+	for _, store := range append(active, deleted...) {
+		n, err := s.reconcileStore(ctx, store, now, dryRun)
+		if err != nil {
+			return result, err
+		}
+		result.Stores++
+		if n > 0 {
+			result.DriftedStores++
+			result.Surveys += n
+		}
+	}
+	return result, nil
+}
+
+func (s *service) reconcileStore(ctx context.Context, store *store_domain.Store, at common_vo.Timestamp, dryRun bool) (int64, error) {
+	snapshot := newStoreSnapshot(store)
+	if dryRun {
+		return s.surveys.CountStoreSnapshotDrift(ctx, snapshot)
+	}
+	return s.surveys.SyncStoreSnapshot(ctx, snapshot, at)
+}
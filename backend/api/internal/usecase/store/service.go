@@ -9,36 +9,95 @@ import (
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
 
 	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+)
+
+var (
+	// ErrStoreNotFound は指定した店舗が存在しない場合に返される。
+	ErrStoreNotFound = errors.New("店舗が見つかりません")
+	// ErrStoreHasLiveSurveys はゴミ箱にない（論理削除されていない）アンケートが紐づく店舗を完全削除しようとした場合に返される。
+	ErrStoreHasLiveSurveys = errors.New("この店舗にはゴミ箱にないアンケートが紐づいているため完全削除できません")
 )
 
 // Service は店舗に関するアプリケーションサービス。
 type Service interface {
 	Save(context.Context, *store_domain.Store) error
 	FindByID(context.Context, store_vo.ID) (*store_domain.Store, error)
+	FindByIDIncludingDeleted(context.Context, store_vo.ID) (*store_domain.Store, error)
 	FindByPrefecture(context.Context, store_vo.Prefecture, common_vo.Pagination) ([]*store_domain.Store, error)
 	FindByArea(context.Context, store_vo.Area, common_vo.Pagination) ([]*store_domain.Store, error)
-	Search(context.Context, store_domain.SearchFilter, common_vo.SortKey, common_vo.Pagination) ([]*store_domain.Store, int64, error)
-	Delete(context.Context, store_vo.ID) error
+	Search(context.Context, store_domain.SearchFilter, common_vo.SortKey, common_vo.Pagination) ([]*store_domain.Store, common_vo.PageInfo, error)
+	Delete(context.Context, store_vo.ID, DeleteOptions) (DeleteResult, error)
+	Restore(context.Context, store_vo.ID) (*store_domain.Store, error)
+	Purge(context.Context, store_vo.ID) (*store_domain.Store, error)
+	ListDeleted(context.Context, common_vo.Pagination) ([]*store_domain.Store, int64, error)
+	ReconcileSurveys(ctx context.Context, dryRun bool) (ReconcileResult, error)
+	RefreshStats(context.Context, store_vo.ID) error
+	RecalculateStats(context.Context) (RecalculateResult, error)
+	Match(context.Context, MatchQuery, int) ([]Match, error)
+}
+
+// SurveyRepo は店舗の変更・削除・復元に合わせて紐づくアンケートを整合させるためのリポジトリ。
+type SurveyRepo interface {
+	CountByStore(context.Context, store_vo.ID) (int64, error)
+	CountLiveByStore(context.Context, store_vo.ID) (int64, error)
+	SoftDeleteByStore(ctx context.Context, storeID store_vo.ID, at common_vo.Timestamp) (int64, error)
+	RestoreByStore(ctx context.Context, storeID store_vo.ID, deletedAt, at common_vo.Timestamp) (int64, error)
+	ReassignStore(ctx context.Context, from store_vo.ID, to survey_domain.StoreSnapshot, at common_vo.Timestamp) (int64, error)
+	PurgeDeletedByStore(context.Context, store_vo.ID) (int64, error)
+	SyncStoreSnapshot(ctx context.Context, snapshot survey_domain.StoreSnapshot, at common_vo.Timestamp) (int64, error)
+	CountStoreSnapshotDrift(context.Context, survey_domain.StoreSnapshot) (int64, error)
+	StoreStats(context.Context, store_vo.ID) (store_vo.Stats, error)
+	AllStoreStats(context.Context) (map[string]store_vo.Stats, error)
+}
+
+// Transactor は複数の永続化処理を 1 つのトランザクションで実行する。
+// fn に渡されるコンテキストをリポジトリ呼び出しに使うことで同じトランザクションに参加する。
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
 }
 
 type service struct {
-	repo store_domain.Repo
+	repo         store_domain.Repo
+	surveys      SurveyRepo
+	tx           Transactor
+	deletePolicy DeletePolicy
 }
 
 // NewService は StoreService を生成する。
-func NewService(repo store_domain.Repo) Service {
+// deletePolicy は DeleteOptions でポリシーが指定されなかった場合の既定値で、空の場合は block となる。
+func NewService(repo store_domain.Repo, surveys SurveyRepo, tx Transactor, deletePolicy DeletePolicy) Service {
 	if repo == nil {
 		panic("store usecase: repo is nil")
 	}
-	return &service{repo: repo}
+	if surveys == nil {
+		panic("store usecase: survey repo is nil")
+	}
+	if tx == nil {
+		panic("store usecase: transactor is nil")
+	}
+	if deletePolicy == "" {
+		deletePolicy = DeletePolicyBlock
+	}
+	return &service{repo: repo, surveys: surveys, tx: tx, deletePolicy: deletePolicy}
 }
 
 // Save は店舗情報を永続化する。
+// アンケートは店舗名・都道府県などを複製して保持しているため、変更があれば同じトランザクションで反映する。
+// 統計はリクエストから受け取らず、保存のたびにアンケートから集計し直す。
 func (s *service) Save(ctx context.Context, store *store_domain.Store) error {
 	if store == nil {
 		return errors.New("store usecase: store is nil")
 	}
-	return s.repo.Save(ctx, store)
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, store); err != nil {
+			return err
+		}
+		if _, err := s.surveys.SyncStoreSnapshot(ctx, newStoreSnapshot(store), common_vo.NowTimestamp()); err != nil {
+			return err
+		}
+		return s.applyRefreshedStats(ctx, store)
+	})
 }
 
 // FindByID は店舗IDで取得する。
@@ -56,13 +115,97 @@ func (s *service) FindByArea(ctx context.Context, area store_vo.Area, page commo
 	return s.repo.FindByArea(ctx, area, page)
 }
 
-// Search は任意条件で店舗一覧を取得する。
-func (s *service) Search(ctx context.Context, filter store_domain.SearchFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*store_domain.Store, int64, error) {
+// Search は任意条件で店舗一覧を取得する。ページ番号またはカーソルで位置を指定する。
+func (s *service) Search(ctx context.Context, filter store_domain.SearchFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*store_domain.Store, common_vo.PageInfo, error) {
 	fmt.Println("サーチがよばれたよ")
 	return s.repo.Search(ctx, filter, sort, page)
 }
 
-// Delete は店舗を削除する。
-func (s *service) Delete(ctx context.Context, id store_vo.ID) error {
-	return s.repo.Delete(ctx, id)
+// FindByIDIncludingDeleted は論理削除済みも含めて店舗IDで取得する。
+func (s *service) FindByIDIncludingDeleted(ctx context.Context, id store_vo.ID) (*store_domain.Store, error) {
+	return s.repo.FindByIDIncludingDeleted(ctx, id)
+}
+
+// Restore は論理削除された店舗を復元する。
+// cascade ポリシーで店舗と同時に論理削除されたアンケートも合わせて復元する。
+func (s *service) Restore(ctx context.Context, id store_vo.ID) (*store_domain.Store, error) {
+	var restored *store_domain.Store
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		store, err := s.findIncludingDeleted(ctx, id)
+		if err != nil {
+			return err
+		}
+		deletedAt := store.DeletedAt()
+		now := common_vo.NowTimestamp()
+		if err := store.Restore(now); err != nil {
+			return err
+		}
+		if err := s.repo.Save(ctx, store); err != nil {
+			return err
+		}
+		if _, err := s.surveys.RestoreByStore(ctx, id, *deletedAt, now); err != nil {
+			return err
+		}
+		if err := s.applyRefreshedStats(ctx, store); err != nil {
+			return err
+		}
+		restored = store
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Purge は論理削除済みの店舗を物理削除し、削除した店舗を返す。
+// 誤操作を防ぐため、論理削除されていない店舗は store_domain.ErrNotDeleted を返して削除しない。
+// 店舗に紐づく論理削除済みのアンケートも、存在しない店舗を参照し続けないよう合わせて物理削除する。
+// 論理削除されていないアンケートが残っている場合は、それらが存在しない店舗を参照することになるため ErrStoreHasLiveSurveys を返す。
+func (s *service) Purge(ctx context.Context, id store_vo.ID) (*store_domain.Store, error) {
+	var purged *store_domain.Store
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		store, err := s.findIncludingDeleted(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !store.IsDeleted() {
+			return store_domain.ErrNotDeleted
+		}
+		live, err := s.surveys.CountLiveByStore(ctx, id)
+		if err != nil {
+			return err
+		}
+		if live > 0 {
+			return ErrStoreHasLiveSurveys
+		}
+		if _, err := s.surveys.PurgeDeletedByStore(ctx, id); err != nil {
+			return err
+		}
+		if err := s.repo.Purge(ctx, id); err != nil {
+			return err
+		}
+		purged = store
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+// ListDeleted は論理削除された店舗をページング取得する。
+func (s *service) ListDeleted(ctx context.Context, page common_vo.Pagination) ([]*store_domain.Store, int64, error) {
+	return s.repo.FindDeleted(ctx, page)
+}
+
+func (s *service) findIncludingDeleted(ctx context.Context, id store_vo.ID) (*store_domain.Store, error) {
+	store, err := s.repo.FindByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, ErrStoreNotFound
+	}
+	return store, nil
 }
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+)
+
+// RecalculateResult は全店舗の統計を再集計した結果を表す。
+type RecalculateResult struct {
+	Stores            int
+	StoresWithSurveys int
+}
+
+// RefreshStats は店舗に紐づく承認済みアンケートから統計を再集計して保存する。
+// アンケートの登録・更新・削除・承認のたびに survey ユースケースから呼ばれる。
+func (s *service) RefreshStats(ctx context.Context, id store_vo.ID) error {
+	_, err := s.refreshStats(ctx, id)
+	return err
+}
+
+// RecalculateStats は全店舗の統計をアンケートから再集計する。
+// 集計ロジックの変更や手動でのデータ修正の後に、保存済みの統計を作り直すために使う。
+func (s *service) RecalculateStats(ctx context.Context) (RecalculateResult, error) {
+	statsByStore, err := s.surveys.AllStoreStats(ctx)
+	if err != nil {
+		return RecalculateResult{}, err
+	}
+	active, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return RecalculateResult{}, err
+	}
+	deleted, _, err := s.repo.FindDeleted(ctx, common_vo.Pagination{})
+	if err != nil {
+		return RecalculateResult{}, err
+	}
+
+	var result RecalculateResult
+	for _, store := range append(active, deleted...) {
+		stats := statsByStore[store.ID().Value()]
+		if err := s.repo.UpdateStats(ctx, store.ID(), stats); err != nil {
+			return result, err
+		}
+		result.Stores++
+		if !stats.IsZero() {
+			result.StoresWithSurveys++
+		}
+	}
+	return result, nil
+}
+
+func (s *service) refreshStats(ctx context.Context, id store_vo.ID) (store_vo.Stats, error) {
+	stats, err := s.surveys.StoreStats(ctx, id)
+	if err != nil {
+		return store_vo.Stats{}, err
+	}
+	if err := s.repo.UpdateStats(ctx, id, stats); err != nil {
+		return store_vo.Stats{}, err
+	}
+	return stats, nil
+}
+
+// applyRefreshedStats は統計を再集計して保存し、返却する店舗にも反映する。
+func (s *service) applyRefreshedStats(ctx context.Context, store *store_domain.Store) error {
+	stats, err := s.refreshStats(ctx, store.ID())
+	if err != nil {
+		return err
+	}
+	store.ApplyStats(stats)
+	return nil
+}
@@ -0,0 +1,158 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	submission_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/submission"
+
+	submission_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/submission"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+)
+
+// ErrSubmissionNotFound は指定した投稿が存在しない場合に返される。
+var ErrSubmissionNotFound = errors.New("投稿が見つかりません")
+
+// SurveyCreator は変換したアンケートを店舗の統計を更新せずに登録する。survey ユースケースが満たす。
+type SurveyCreator interface {
+	CreateWithoutStats(context.Context, *survey_domain.Survey) error
+}
+
+// StatsUpdater は登録したアンケートを店舗の統計に反映する。store ユースケースが満たす。
+type StatsUpdater interface {
+	RefreshStats(context.Context, store_vo.ID) error
+}
+
+// Transactor は複数の永続化処理を 1 つのトランザクションで実行する。
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+// Service は一般投稿に関するアプリケーションサービス。
+type Service interface {
+	Submit(ctx context.Context, payload submission_domain.Payload, clientIP string) (*submission_domain.Submission, error)
+	FindByID(ctx context.Context, id submission_vo.ID) (*submission_domain.Submission, error)
+	List(ctx context.Context, filter submission_domain.Filter, page common_vo.Pagination) ([]*submission_domain.Submission, int64, error)
+	Convert(ctx context.Context, id submission_vo.ID, survey *survey_domain.Survey, actor string) (*submission_domain.Submission, error)
+	Reject(ctx context.Context, id submission_vo.ID, actor, reason string) (*submission_domain.Submission, error)
+}
+
+type service struct {
+	repo    submission_domain.Repo
+	surveys SurveyCreator
+	stats   StatsUpdater
+	tx      Transactor
+}
+
+// NewService は SubmissionService を生成する。
+func NewService(repo submission_domain.Repo, surveys SurveyCreator, stats StatsUpdater, tx Transactor) Service {
+	if repo == nil {
+		panic("submission usecase: repo is nil")
+	}
+	if surveys == nil {
+		panic("submission usecase: survey creator is nil")
+	}
+	if stats == nil {
+		panic("submission usecase: stats updater is nil")
+	}
+	if tx == nil {
+		panic("submission usecase: transactor is nil")
+	}
+	return &service{repo: repo, surveys: surveys, stats: stats, tx: tx}
+}
+
+// Submit は一般ユーザーの投稿を pending として保存する。
+func (s *service) Submit(ctx context.Context, payload submission_domain.Payload, clientIP string) (*submission_domain.Submission, error) {
+	id, err := submission_vo.NewID(primitive.NewObjectID().Hex())
+	if err != nil {
+		return nil, err
+	}
+	entity, err := submission_domain.NewSubmission(id, payload, submission_domain.WithClientIP(clientIP))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// FindByID は投稿IDで取得する。
+func (s *service) FindByID(ctx context.Context, id submission_vo.ID) (*submission_domain.Submission, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// List は条件に合う投稿をページング取得する。
+func (s *service) List(ctx context.Context, filter submission_domain.Filter, page common_vo.Pagination) ([]*submission_domain.Submission, int64, error) {
+	return s.repo.Find(ctx, filter, page)
+}
+
+// Convert は投稿から組み立てたアンケートを登録し、投稿を変換済みにする。
+// 処理済みの投稿の場合は submission_domain.ErrNotPending を返し、アンケートは登録しない。
+// 投稿を確認待ちから変換済みにできた場合だけアンケートを登録し、両方を 1 つのトランザクションで行う。
+// 同じ投稿を同時に変換しても登録されるアンケートは 1 件で、登録に失敗した場合は投稿も確認待ちのまま残る。
+// 店舗の統計はトランザクションの確定後に更新し、更新に失敗しても変換は取り消さずログに残す。
+func (s *service) Convert(ctx context.Context, id submission_vo.ID, survey *survey_domain.Survey, actor string) (*submission_domain.Submission, error) {
+	if survey == nil {
+		return nil, errors.New("submission usecase: survey is nil")
+	}
+	entity, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.MarkConverted(survey.ID(), actor, common_vo.NowTimestamp()); err != nil {
+		return nil, err
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.repo.SaveIfPending(ctx, entity)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return submission_domain.ErrNotPending
+		}
+		return s.surveys.CreateWithoutStats(ctx, survey)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.stats.RefreshStats(ctx, survey.StoreID()); err != nil {
+		log.Printf("submission usecase: failed to refresh stats for store %s: %v", survey.StoreID().Value(), err)
+	}
+	return entity, nil
+}
+
+// Reject は投稿を却下する。
+func (s *service) Reject(ctx context.Context, id submission_vo.ID, actor, reason string) (*submission_domain.Submission, error) {
+	entity, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Reject(actor, reason, common_vo.NowTimestamp()); err != nil {
+		return nil, err
+	}
+	claimed, err := s.repo.SaveIfPending(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, submission_domain.ErrNotPending
+	}
+	return entity, nil
+}
+
+func (s *service) load(ctx context.Context, id submission_vo.ID) (*submission_domain.Submission, error) {
+	entity, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, ErrSubmissionNotFound
+	}
+	return entity, nil
+}
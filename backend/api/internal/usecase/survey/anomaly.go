@@ -0,0 +1,83 @@
+package survey
+
+import (
+	"context"
+	"math"
+
+	statistics_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/statistics"
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+)
+
+const (
+	// MinAnomalyReferenceSize は外れ値の判定に必要な比較対象のアンケート件数。
+	// 件数が少ない分布は中央値やばらつきが安定しないため判定しない。
+	MinAnomalyReferenceSize = 10
+	// AnomalyThreshold はロバスト z スコアの絶対値がこれを超える回答を外れ値とみなす閾値。
+	AnomalyThreshold = 3.5
+
+	// minAnomalySpread は判定に使うばらつきの下限。平均稼ぎ・待機時間は整数で回答されるため、
+	// ほとんどの回答が同じ値の分布でも 1 つ違うだけで外れ値とならないよう 1 単位分の幅を持たせる。
+	minAnomalySpread = 1.0
+)
+
+// detectAnomalies は平均稼ぎ・待機時間の回答を、同じ店舗と同じ業種の集計対象のアンケートの分布と比べる。
+// 比較にはアンケート自身と、未確認の外れ値を含むアンケートを使わない。
+func (s *service) detectAnomalies(ctx context.Context, survey *survey_domain.Survey) ([]survey_domain.Anomaly, error) {
+	id := survey.ID()
+	storeID := survey.StoreID()
+	industry := survey.StoreIndustry()
+	references := []struct {
+		reference survey_domain.AnomalyReference
+		filter    survey_domain.AnswerFilter
+	}{
+		{survey_domain.AnomalyReferenceStore, survey_domain.AnswerFilter{StoreID: &storeID, ExcludeID: &id}},
+		{survey_domain.AnomalyReferenceIndustry, survey_domain.AnswerFilter{Industry: &industry, ExcludeID: &id}},
+	}
+
+	var anomalies []survey_domain.Anomaly
+	for _, ref := range references {
+		answers, err := s.repo.NumericAnswers(ctx, ref.filter)
+		if err != nil {
+			return nil, err
+		}
+		if a, ok := checkAnomaly(survey_domain.AnomalyFieldAverageEarning, ref.reference, float64(survey.AverageEarning().Value()), answers.AverageEarnings); ok {
+			anomalies = append(anomalies, a)
+		}
+		if a, ok := checkAnomaly(survey_domain.AnomalyFieldWaitTimeHours, ref.reference, float64(survey.WaitTime().Value()), answers.WaitTimeHours); ok {
+			anomalies = append(anomalies, a)
+		}
+	}
+	return anomalies, nil
+}
+
+// checkAnomaly は value が reference の分布から AnomalyThreshold を超えて外れているかを判定する。
+func checkAnomaly(field survey_domain.AnomalyField, reference survey_domain.AnomalyReference, value float64, values []float64) (survey_domain.Anomaly, bool) {
+	if len(values) < MinAnomalyReferenceSize {
+		return survey_domain.Anomaly{}, false
+	}
+	median, spread := statistics_domain.RobustSpread(statistics_domain.Sorted(values))
+	spread = math.Max(spread, minAnomalySpread)
+	score := statistics_domain.RobustZScore(value, median, spread)
+	if math.Abs(score) <= AnomalyThreshold {
+		return survey_domain.Anomaly{}, false
+	}
+	return survey_domain.Anomaly{
+		Field:     field,
+		Reference: reference,
+		Value:     value,
+		Median:    median,
+		Lower:     median - AnomalyThreshold*spread,
+		Upper:     median + AnomalyThreshold*spread,
+		Score:     score,
+	}, true
+}
+
+// flagAnomalies は survey の外れ値の指摘を判定し直す。
+func (s *service) flagAnomalies(ctx context.Context, survey *survey_domain.Survey) error {
+	anomalies, err := s.detectAnomalies(ctx, survey)
+	if err != nil {
+		return err
+	}
+	survey.FlagAnomalies(anomalies)
+	return nil
+}
@@ -0,0 +1,124 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+)
+
+// DuplicatePolicy は重複の疑いがあるアンケートを登録するときの扱いを表す。
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyAllow は重複の疑いがあっても登録し、管理画面での確認に任せる。
+	DuplicatePolicyAllow DuplicatePolicy = "allow"
+	// DuplicatePolicyBlock は重複の疑いがあるアンケートの登録を拒否する。
+	DuplicatePolicyBlock DuplicatePolicy = "block"
+)
+
+const (
+	// DefaultDuplicateWindow は重複を探す作成日時の範囲（前後それぞれ）の既定値。
+	DefaultDuplicateWindow = 30 * 24 * time.Hour
+	// DuplicateTextThreshold はコメントの推定 Jaccard 係数がこれ以上の場合に文章が重複しているとみなす閾値。
+	DuplicateTextThreshold = 0.8
+)
+
+var (
+	// ErrInvalidDuplicatePolicy は未知の重複ポリシーが指定された場合に返される。
+	ErrInvalidDuplicatePolicy = errors.New("重複ポリシーは allow / block のいずれかを指定してください")
+	// ErrDuplicateSurvey は block ポリシーで重複の疑いがあるアンケートを登録しようとした場合に返される。
+	ErrDuplicateSurvey = errors.New("重複の疑いがあるアンケートが登録されています")
+)
+
+// ParseDuplicatePolicy は文字列から DuplicatePolicy を生成する。
+func ParseDuplicatePolicy(raw string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(strings.ToLower(strings.TrimSpace(raw))); policy {
+	case DuplicatePolicyAllow, DuplicatePolicyBlock:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidDuplicatePolicy, raw)
+	}
+}
+
+// CreateOptions はアンケート登録時の指定。DuplicatePolicy が空の場合はサービスの既定ポリシーを使う。
+type CreateOptions struct {
+	DuplicatePolicy DuplicatePolicy
+}
+
+// DuplicateError は block ポリシーで登録を拒否した際に、重複の疑いがあるアンケートを伝える。
+// errors.Is(err, ErrDuplicateSurvey) で判定できる。
+type DuplicateError struct {
+	Candidates []survey_domain.DuplicateCandidate
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%s (%d 件)", ErrDuplicateSurvey.Error(), len(e.Candidates))
+}
+
+func (e *DuplicateError) Unwrap() error {
+	return ErrDuplicateSurvey
+}
+
+// FindDuplicates は survey の作成日時の前後 duplicateWindow に作成されたアンケートから、重複の疑いがあるものを返す。
+// 同じメールアドレス、同じ店舗・稼働時期で数値の回答がすべて一致するもの、コメントの文章がほぼ同じものを対象とする。
+// 期間内の全件とは比べず、メールアドレス・コメントの LSH の帯 (Survey.DuplicateKeys) か店舗・稼働時期が一致するものだけを取得して比べる。
+//
+// block ポリシーでの確認と保存は別々に行うため、ほぼ同時に送られた重複どうしはどちらも登録されうる。
+// 登録を拒否するのは利用者への注意を目的としたもので、見逃した重複は管理画面の重複確認で扱う前提としてこの競合は許容する。
+func (s *service) FindDuplicates(ctx context.Context, survey *survey_domain.Survey) ([]survey_domain.DuplicateCandidate, error) {
+	if survey == nil {
+		return nil, errors.New("survey usecase: survey is nil")
+	}
+	created := survey.CreatedAt().Value()
+	others, err := s.repo.FindDuplicateCandidates(ctx, survey_domain.DuplicateQuery{
+		StoreID:       survey.StoreID(),
+		VisitedPeriod: survey.VisitedPeriod(),
+		Keys:          survey.DuplicateKeys(),
+		From:          created.Add(-s.duplicateWindow),
+		To:            created.Add(s.duplicateWindow),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature := survey.CommentSignature()
+	var candidates []survey_domain.DuplicateCandidate
+	for _, other := range others {
+		if other.Equals(survey) {
+			continue
+		}
+		candidate := survey_domain.DuplicateCandidate{Survey: other}
+		if email := survey.EmailAddress(); !email.IsZero() && strings.EqualFold(email.Value(), other.EmailAddress().Value()) {
+			candidate.Reasons = append(candidate.Reasons, survey_domain.DuplicateReasonEmail)
+		}
+		if sameAnswers(survey, other) {
+			candidate.Reasons = append(candidate.Reasons, survey_domain.DuplicateReasonAnswers)
+		}
+		if signature != nil {
+			candidate.TextSimilarity = signature.Similarity(other.CommentSignature())
+			if candidate.TextSimilarity >= DuplicateTextThreshold {
+				candidate.Reasons = append(candidate.Reasons, survey_domain.DuplicateReasonText)
+			}
+		}
+		if len(candidate.Reasons) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// sameAnswers は同じ店舗・稼働時期で、勤務形態と数値の回答がすべて一致するかを返す。
+func sameAnswers(a, b *survey_domain.Survey) bool {
+	return a.StoreID().Equals(b.StoreID()) &&
+		a.VisitedPeriod().Equals(b.VisitedPeriod()) &&
+		a.WorkType().Equals(b.WorkType()) &&
+		a.Age().Equals(b.Age()) &&
+		a.SpecScore().Equals(b.SpecScore()) &&
+		a.WaitTime().Equals(b.WaitTime()) &&
+		a.AverageEarning().Equals(b.AverageEarning()) &&
+		a.Rating().Equals(b.Rating())
+}
@@ -3,56 +3,218 @@ package survey
 import (
 	"context"
 	"errors"
+	"log"
+	"time"
 
 	common_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/common"
 	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
 	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
 
+	store_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/store"
 	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
 )
 
+var (
+	// ErrSurveyNotFound は指定したアンケートが存在しない場合に返される。
+	ErrSurveyNotFound = errors.New("アンケートが見つかりません")
+	// ErrStoreDeleted は紐づく店舗がゴミ箱にある、または存在しないアンケートを復元しようとした場合に返される。
+	ErrStoreDeleted = errors.New("紐づく店舗が削除されているため復元できません。先に店舗を復元してください")
+)
+
 // Service はアンケートに関するアプリケーションサービス。
 type Service interface {
 	Create(context.Context, *survey_domain.Survey) error
+	CreateWithOptions(context.Context, *survey_domain.Survey, CreateOptions) error
+	CreateWithoutStats(context.Context, *survey_domain.Survey) error
 	Update(context.Context, *survey_domain.Survey) error
-	Delete(context.Context, survey_vo.ID) error
+	Delete(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
+	Restore(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
+	Purge(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
+	ListDeleted(context.Context, common_vo.Pagination) ([]*survey_domain.Survey, int64, error)
 	FindByID(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
+	FindPublishedByID(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
+	FindByIDIncludingDeleted(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
 	GetByStore(context.Context, store_vo.ID, common_vo.SortKey, common_vo.Pagination) ([]*survey_domain.Survey, int64, error)
 	GetByPrefecture(context.Context, store_vo.Prefecture, common_vo.SortKey, common_vo.Pagination) ([]*survey_domain.Survey, int64, error)
-	ListAdmin(context.Context, survey_domain.AdminFilter, common_vo.SortKey, common_vo.Pagination) ([]*survey_domain.Survey, int64, error)
+	ListAdmin(context.Context, survey_domain.AdminFilter, common_vo.SortKey, common_vo.Pagination) ([]*survey_domain.Survey, common_vo.PageInfo, error)
+	Approve(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error)
+	Reject(ctx context.Context, id survey_vo.ID, reviewer, reason string) (*survey_domain.Survey, error)
+	ConfirmAnomalies(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error)
+	FindDuplicates(context.Context, *survey_domain.Survey) ([]survey_domain.DuplicateCandidate, error)
+}
+
+// StatsUpdater はアンケートの変更を店舗の統計に反映する。store ユースケースが満たす。
+type StatsUpdater interface {
+	RefreshStats(context.Context, store_vo.ID) error
+}
+
+// StoreReader はアンケートが紐づく店舗を取得する。論理削除済み・存在しない場合は (nil, nil) を返す。store ユースケースが満たす。
+type StoreReader interface {
+	FindByID(context.Context, store_vo.ID) (*store_domain.Store, error)
 }
 
 type service struct {
-	repo survey_domain.Repo
+	repo            survey_domain.Repo
+	stats           StatsUpdater
+	stores          StoreReader
+	duplicatePolicy DuplicatePolicy
+	duplicateWindow time.Duration
 }
 
 // NewService は SurveyService を生成する。
-func NewService(repo survey_domain.Repo) Service {
+// duplicatePolicy が空の場合は DuplicatePolicyAllow、duplicateWindow が 0 以下の場合は DefaultDuplicateWindow を使う。
+func NewService(repo survey_domain.Repo, stats StatsUpdater, stores StoreReader, duplicatePolicy DuplicatePolicy, duplicateWindow time.Duration) Service {
 	if repo == nil {
 		panic("survey usecase: repo is nil")
 	}
-	return &service{repo: repo}
+	if stats == nil {
+		panic("survey usecase: stats updater is nil")
+	}
+	if stores == nil {
+		panic("survey usecase: store reader is nil")
+	}
+	if duplicatePolicy == "" {
+		duplicatePolicy = DuplicatePolicyAllow
+	}
+	if duplicateWindow <= 0 {
+		duplicateWindow = DefaultDuplicateWindow
+	}
+	return &service{repo: repo, stats: stats, stores: stores, duplicatePolicy: duplicatePolicy, duplicateWindow: duplicateWindow}
 }
 
-// Create はアンケートを新規登録する。
+// Create は既定の重複ポリシーでアンケートを新規登録する。
 func (s *service) Create(ctx context.Context, survey *survey_domain.Survey) error {
+	return s.CreateWithOptions(ctx, survey, CreateOptions{})
+}
+
+// CreateWithOptions はアンケートを新規登録する。数値の回答に外れ値があれば指摘を付けて保存する。
+// block ポリシーで重複の疑いがあるアンケートが見つかった場合は *DuplicateError を返して登録しない。
+func (s *service) CreateWithOptions(ctx context.Context, survey *survey_domain.Survey, opts CreateOptions) error {
+	if err := s.create(ctx, survey, opts); err != nil {
+		return err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return nil
+}
+
+// CreateWithoutStats は既定の重複ポリシーでアンケートを新規登録し、店舗の統計は更新しない。
+// トランザクション内で登録する場合に使う。統計の更新に失敗するとトランザクションごと中断されるため、
+// 呼び出し側が確定後に RefreshStats で統計を更新する。
+func (s *service) CreateWithoutStats(ctx context.Context, survey *survey_domain.Survey) error {
+	return s.create(ctx, survey, CreateOptions{})
+}
+
+func (s *service) create(ctx context.Context, survey *survey_domain.Survey, opts CreateOptions) error {
 	if survey == nil {
 		return errors.New("survey usecase: survey is nil")
 	}
+	policy := opts.DuplicatePolicy
+	if policy == "" {
+		policy = s.duplicatePolicy
+	}
+	if policy == DuplicatePolicyBlock {
+		candidates, err := s.FindDuplicates(ctx, survey)
+		if err != nil {
+			return err
+		}
+		if len(candidates) > 0 {
+			return &DuplicateError{Candidates: candidates}
+		}
+	}
+	if err := s.flagAnomalies(ctx, survey); err != nil {
+		return err
+	}
 	return s.repo.Save(ctx, survey)
 }
 
-// Update は既存アンケートを更新する。
+// Update は既存アンケートを更新する。店舗が変更された場合は変更前の店舗の統計も更新する。
+// 外れ値は更新後の回答で判定し直し、確認済みの指摘は値が変わっていなければ確認を引き継ぐ。
 func (s *service) Update(ctx context.Context, survey *survey_domain.Survey) error {
 	if survey == nil {
 		return errors.New("survey usecase: survey is nil")
 	}
-	return s.repo.Save(ctx, survey)
+	before, err := s.repo.FindByIDIncludingDeleted(ctx, survey.ID())
+	if err != nil {
+		return err
+	}
+	if err := s.flagAnomalies(ctx, survey); err != nil {
+		return err
+	}
+	if err := s.repo.Save(ctx, survey); err != nil {
+		return err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	if before != nil && !before.StoreID().Equals(survey.StoreID()) {
+		s.refreshStats(ctx, before.StoreID())
+	}
+	return nil
+}
+
+// Delete はアンケートを論理削除し、削除後のアンケートを返す。
+func (s *service) Delete(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	survey, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := survey.MarkDeleted(common_vo.NowTimestamp()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, survey); err != nil {
+		return nil, err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return survey, nil
+}
+
+// Restore は論理削除されたアンケートを復元する。
+// 紐づく店舗がゴミ箱にある場合は、削除済みの店舗を参照する公開中のアンケートにならないよう ErrStoreDeleted を返す。
+func (s *service) Restore(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	survey, err := s.findIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	store, err := s.stores.FindByID(ctx, survey.StoreID())
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, ErrStoreDeleted
+	}
+	if err := survey.Restore(common_vo.NowTimestamp()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, survey); err != nil {
+		return nil, err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return survey, nil
 }
 
-// Delete はアンケートを削除する。
-func (s *service) Delete(ctx context.Context, id survey_vo.ID) error {
-	return s.repo.Delete(ctx, id)
+// Purge は論理削除済みのアンケートを物理削除し、削除したアンケートを返す。
+// 誤操作を防ぐため、論理削除されていないアンケートは survey_domain.ErrNotDeleted を返して削除しない。
+func (s *service) Purge(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	survey, err := s.findIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !survey.IsDeleted() {
+		return nil, survey_domain.ErrNotDeleted
+	}
+	if err := s.repo.Purge(ctx, id); err != nil {
+		return nil, err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return survey, nil
+}
+
+// ListDeleted は論理削除されたアンケートをページング取得する。
+func (s *service) ListDeleted(ctx context.Context, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
+	return s.repo.FindDeleted(ctx, page)
+}
+
+// FindByIDIncludingDeleted は論理削除済みも含めてアンケートIDで取得する。
+func (s *service) FindByIDIncludingDeleted(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	return s.repo.FindByIDIncludingDeleted(ctx, id)
 }
 
 // FindByID はアンケートIDで取得する。
@@ -70,7 +232,87 @@ func (s *service) GetByPrefecture(ctx context.Context, pref store_vo.Prefecture,
 	return s.repo.FindByPrefecture(ctx, pref, sort, page)
 }
 
-// ListAdmin は管理者用にフィルタ付きでアンケートをページング取得する。
-func (s *service) ListAdmin(ctx context.Context, filter survey_domain.AdminFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, int64, error) {
+// ListAdmin は管理者用にフィルタ付きでアンケートをページング取得する。ページ番号またはカーソルで位置を指定する。
+func (s *service) ListAdmin(ctx context.Context, filter survey_domain.AdminFilter, sort common_vo.SortKey, page common_vo.Pagination) ([]*survey_domain.Survey, common_vo.PageInfo, error) {
 	return s.repo.FindAdmin(ctx, filter, sort, page)
 }
+
+// FindPublishedByID は承認済みのアンケートをIDで取得する。
+func (s *service) FindPublishedByID(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	return s.repo.FindPublishedByID(ctx, id)
+}
+
+// Approve はアンケートを承認して公開する。
+func (s *service) Approve(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error) {
+	survey, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	survey.Approve(reviewer, common_vo.NowTimestamp())
+	if err := s.repo.Save(ctx, survey); err != nil {
+		return nil, err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return survey, nil
+}
+
+// Reject はアンケートを非承認にして公開対象から外す。
+func (s *service) Reject(ctx context.Context, id survey_vo.ID, reviewer, reason string) (*survey_domain.Survey, error) {
+	survey, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	survey.Reject(reviewer, reason, common_vo.NowTimestamp())
+	if err := s.repo.Save(ctx, survey); err != nil {
+		return nil, err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return survey, nil
+}
+
+// ConfirmAnomalies は外れ値の指摘を管理者が確認し、アンケートを統計・ランキングの集計に含める。
+// 指摘がない場合は survey_domain.ErrNoAnomalies を返す。
+func (s *service) ConfirmAnomalies(ctx context.Context, id survey_vo.ID, reviewer string) (*survey_domain.Survey, error) {
+	survey, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := survey.ConfirmAnomalies(reviewer, common_vo.NowTimestamp()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, survey); err != nil {
+		return nil, err
+	}
+	s.refreshStats(ctx, survey.StoreID())
+	return survey, nil
+}
+
+// refreshStats は店舗の統計を更新する。アンケート自体の保存は完了しているため、
+// 失敗してもエラーにはせずログに残し、統計の再集計 API で復旧させる。
+func (s *service) refreshStats(ctx context.Context, storeID store_vo.ID) {
+	if err := s.stats.RefreshStats(ctx, storeID); err != nil {
+		log.Printf("survey usecase: failed to refresh stats for store %s: %v", storeID.Value(), err)
+	}
+}
+
+func (s *service) findIncludingDeleted(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	survey, err := s.repo.FindByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil {
+		return nil, ErrSurveyNotFound
+	}
+	return survey, nil
+}
+
+func (s *service) load(ctx context.Context, id survey_vo.ID) (*survey_domain.Survey, error) {
+	survey, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if survey == nil {
+		return nil, ErrSurveyNotFound
+	}
+	return survey, nil
+}
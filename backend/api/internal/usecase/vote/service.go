@@ -0,0 +1,178 @@
+package vote
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	store_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/store"
+	survey_vo "github.com/sngm3741/makoto-club-services/api/internal/domain/vo/survey"
+
+	survey_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/survey"
+	vote_domain "github.com/sngm3741/makoto-club-services/api/internal/domain/vote"
+)
+
+// ErrSurveyNotFound は投票先のアンケートが公開されていない場合に返される。
+var ErrSurveyNotFound = errors.New("アンケートが見つかりません")
+
+// Service は「参考になった」投票に関するアプリケーションサービス。
+type Service interface {
+	MarkHelpful(ctx context.Context, surveyID survey_vo.ID, voter Voter) (Result, error)
+	UnmarkHelpful(ctx context.Context, surveyID survey_vo.ID, voter Voter) (Result, error)
+}
+
+// Voter は投票したクライアントを表す。ハッシュ化してから保存するため、生の値は永続化されない。
+type Voter struct {
+	IP          string
+	DeviceToken string
+}
+
+// Result は投票・取り消し後の状態を表す。
+// Changed は今回の操作で件数が変わったかどうかで、既に投票済み・未投票の場合は false となる。
+type Result struct {
+	SurveyID     survey_vo.ID
+	HelpfulCount int
+	Voted        bool
+	Changed      bool
+}
+
+// SurveyRepo は投票先のアンケートを確認し、件数を増減するためのリポジトリ。
+type SurveyRepo interface {
+	FindPublishedByID(context.Context, survey_vo.ID) (*survey_domain.Survey, error)
+	IncrementHelpful(ctx context.Context, id survey_vo.ID, delta int) error
+}
+
+// StatsUpdater は投票の件数の変化を店舗の統計（参考になった数の合計）に反映する。store ユースケースが満たす。
+type StatsUpdater interface {
+	RefreshStats(context.Context, store_vo.ID) error
+}
+
+// Transactor は複数の永続化処理を 1 つのトランザクションで実行する。
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+type service struct {
+	repo    vote_domain.Repo
+	surveys SurveyRepo
+	stats   StatsUpdater
+	tx      Transactor
+	salt    string
+}
+
+// NewService は VoteService を生成する。salt は投票者ハッシュの生成に使い、漏洩した場合に IP アドレスを総当たりされにくくする。
+// salt が空の場合はハッシュから IP アドレスを容易に逆算できるため panic する。
+func NewService(repo vote_domain.Repo, surveys SurveyRepo, stats StatsUpdater, tx Transactor, salt string) Service {
+	if repo == nil {
+		panic("vote usecase: repo is nil")
+	}
+	if surveys == nil {
+		panic("vote usecase: survey repo is nil")
+	}
+	if stats == nil {
+		panic("vote usecase: stats updater is nil")
+	}
+	if tx == nil {
+		panic("vote usecase: transactor is nil")
+	}
+	if strings.TrimSpace(salt) == "" {
+		panic("vote usecase: salt is empty")
+	}
+	return &service{repo: repo, surveys: surveys, stats: stats, tx: tx, salt: salt}
+}
+
+// MarkHelpful はアンケートに「参考になった」を付ける。
+// IP アドレスか端末トークンのどちらかで既に投票済みの場合は、件数を変えずに現在の状態を返す。
+func (s *service) MarkHelpful(ctx context.Context, surveyID survey_vo.ID, voter Voter) (Result, error) {
+	hashes, err := vote_domain.NewVoter(s.salt, voter.IP, voter.DeviceToken)
+	if err != nil {
+		return Result{}, err
+	}
+	vote, err := vote_domain.NewVote(surveyID, hashes)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var changed bool
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensurePublished(ctx, surveyID); err != nil {
+			return err
+		}
+		added, err := s.repo.Add(ctx, vote)
+		if err != nil {
+			return err
+		}
+		changed = added
+		if !added {
+			return nil
+		}
+		return s.surveys.IncrementHelpful(ctx, surveyID, 1)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return s.result(ctx, surveyID, true, changed)
+}
+
+// UnmarkHelpful は「参考になった」を取り消す。投票していない場合は件数を変えずに現在の状態を返す。
+func (s *service) UnmarkHelpful(ctx context.Context, surveyID survey_vo.ID, voter Voter) (Result, error) {
+	hashes, err := vote_domain.NewVoter(s.salt, voter.IP, voter.DeviceToken)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var changed bool
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensurePublished(ctx, surveyID); err != nil {
+			return err
+		}
+		removed, err := s.repo.Remove(ctx, surveyID, hashes)
+		if err != nil {
+			return err
+		}
+		changed = removed
+		if !removed {
+			return nil
+		}
+		return s.surveys.IncrementHelpful(ctx, surveyID, -1)
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return s.result(ctx, surveyID, false, changed)
+}
+
+func (s *service) ensurePublished(ctx context.Context, surveyID survey_vo.ID) error {
+	survey, err := s.surveys.FindPublishedByID(ctx, surveyID)
+	if err != nil {
+		return err
+	}
+	if survey == nil {
+		return ErrSurveyNotFound
+	}
+	return nil
+}
+
+// result は更新後の件数を読み直して Result を組み立てる。件数が変わった場合は店舗の統計も更新する。
+// 統計の更新に失敗しても投票は反映済みのため、ログに残して結果を返す。
+func (s *service) result(ctx context.Context, surveyID survey_vo.ID, voted, changed bool) (Result, error) {
+	survey, err := s.surveys.FindPublishedByID(ctx, surveyID)
+	if err != nil {
+		return Result{}, err
+	}
+	if survey == nil {
+		return Result{}, ErrSurveyNotFound
+	}
+	if changed {
+		if err := s.stats.RefreshStats(ctx, survey.StoreID()); err != nil {
+			log.Printf("vote usecase: failed to refresh stats for store %s: %v", survey.StoreID().Value(), err)
+		}
+	}
+	return Result{
+		SurveyID:     surveyID,
+		HelpfulCount: survey.HelpfulCount(),
+		Voted:        voted,
+		Changed:      changed,
+	}, nil
+}
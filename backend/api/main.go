@@ -8,15 +8,30 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/sngm3741/makoto-club-services/api/internal/infrastructure/auth"
+	mongo_infra "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo"
+	admin_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/admin"
+	audit_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/audit"
+	dashboard_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/dashboard"
 	store_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/store"
+	submission_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/submission"
 	survey_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/survey"
+	vote_mongo "github.com/sngm3741/makoto-club-services/api/internal/infrastructure/mongo/vote"
 	interfaces_http "github.com/sngm3741/makoto-club-services/api/internal/interfaces/http"
+	admin_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/admin"
+	audit_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/audit"
+	dashboard_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/dashboard"
+	estimate_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/estimate"
+	statistics_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/statistics"
 	store_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/store"
+	submission_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/submission"
 	survey_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/survey"
+	vote_usecase "github.com/sngm3741/makoto-club-services/api/internal/usecase/vote"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -25,45 +40,141 @@ import (
 // config はアプリ起動時に必要な設定値をまとめた構造体。
 // 環境変数から値を読み取り、欠けている場合は合理的なデフォルトを採用する。
 type config struct {
-	addr             string
-	mongoURI         string
-	mongoDatabase    string
-	storeCollection  string
-	surveyCollection string
-	connectTimeout   time.Duration
-	shutdownTimeout  time.Duration
-	allowedOrigins   []string
-	logger           *log.Logger
+	addr                 string
+	mongoURI             string
+	mongoDatabase        string
+	storeCollection      string
+	surveyCollection     string
+	submissionCollection string
+	adminCollection      string
+	auditCollection      string
+	voteCollection       string
+	voteHashSalt         string
+	storeDeletePolicy    store_usecase.DeletePolicy
+	duplicatePolicy      survey_usecase.DuplicatePolicy
+	duplicateWindow      time.Duration
+	statsMinSampleSize   int
+	estimateRefresh      time.Duration
+	rankingRefresh       time.Duration
+	location             *time.Location
+	connectTimeout       time.Duration
+	shutdownTimeout      time.Duration
+	allowedOrigins       []string
+	adminAuth            auth.Config
+	bootstrapEmail       string
+	bootstrapPass        string
+	logger               *log.Logger
 }
 
 // main は MongoDB との接続、DI、HTTP サーバーの起動/終了処理を行う。
+// 引数でサブコマンドが指定された場合はサーバーを起動せずにそのコマンドを実行する。
 func main() {
 	c := loadConfig()
+	if len(os.Args) > 1 {
+		runCommand(c, os.Args[1], os.Args[2:])
+		return
+	}
+	if c.voteHashSalt == "" {
+		c.logger.Fatalf("VOTE_HASH_SALT is required")
+	}
+
+	verifier, err := auth.NewVerifier(c.adminAuth)
+	if err != nil {
+		c.logger.Fatalf("failed to configure admin token verifier: %v", err)
+	}
+	// 署名鍵がない検証専用の構成では、ログイン/リフレッシュを 503 で断る。
+	var tokens admin_usecase.TokenIssuer
+	if c.adminAuth.CanSign() {
+		signer, err := auth.NewSigner(c.adminAuth)
+		if err != nil {
+			c.logger.Fatalf("failed to configure admin token signer: %v", err)
+		}
+		tokens = signer
+	} else {
+		c.logger.Printf("admin token signing key is not configured; login and refresh are disabled")
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.connectTimeout)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(c.mongoURI))
-	if err != nil {
-		c.logger.Fatalf("failed to connect to MongoDB: %v", err)
+	client := connectMongo(ctx, c)
+	database := client.Database(c.mongoDatabase)
+	transactor := mongo_infra.NewTransactor(client)
+
+	surveyRepo := survey_mongo.NewRepo(database.Collection(c.surveyCollection))
+	if err := surveyRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure survey indexes: %v", err)
+	}
+	storeRepo := store_mongo.NewRepo(
+		database.Collection(c.storeCollection),
+		database.Collection(c.surveyCollection),
+	)
+	if err := storeRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure store indexes: %v", err)
 	}
+	storeService := store_usecase.NewService(storeRepo, surveyRepo, transactor, c.storeDeletePolicy)
+	surveyService := survey_usecase.NewService(surveyRepo, storeService, storeService, c.duplicatePolicy, c.duplicateWindow)
 
-	if err := client.Ping(ctx, readpref.Primary()); err != nil {
-		c.logger.Fatalf("failed to ping MongoDB: %v", err)
+	submissionRepo := submission_mongo.NewRepo(database.Collection(c.submissionCollection))
+	if err := submissionRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure submission indexes: %v", err)
 	}
+	submissionService := submission_usecase.NewService(submissionRepo, surveyService, storeService, transactor)
 
-	database := client.Database(c.mongoDatabase)
-	storeRepo := store_mongo.NewRepo(
+	adminRepo := admin_mongo.NewRepo(database.Collection(c.adminCollection))
+	if err := adminRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure admin indexes: %v", err)
+	}
+	adminService := admin_usecase.NewService(adminRepo, tokens)
+	if c.bootstrapEmail != "" && c.bootstrapPass != "" {
+		created, err := adminService.Bootstrap(ctx, c.bootstrapEmail, c.bootstrapPass)
+		if err != nil {
+			c.logger.Fatalf("failed to bootstrap admin: %v", err)
+		}
+		if created {
+			c.logger.Printf("bootstrapped initial admin %s", c.bootstrapEmail)
+		}
+	}
+
+	auditRepo := audit_mongo.NewRepo(database.Collection(c.auditCollection))
+	if err := auditRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure audit indexes: %v", err)
+	}
+	auditService := audit_usecase.NewService(auditRepo)
+
+	statisticsService := statistics_usecase.NewService(storeRepo, surveyRepo, c.statsMinSampleSize)
+
+	voteRepo := vote_mongo.NewRepo(database.Collection(c.voteCollection))
+	if err := voteRepo.EnsureIndexes(ctx); err != nil {
+		c.logger.Fatalf("failed to ensure vote indexes: %v", err)
+	}
+	voteService := vote_usecase.NewService(voteRepo, surveyRepo, storeService, transactor, c.voteHashSalt)
+
+	dashboardRepo := dashboard_mongo.NewRepo(
 		database.Collection(c.storeCollection),
 		database.Collection(c.surveyCollection),
+		database.Collection(c.submissionCollection),
 	)
-	storeService := store_usecase.NewService(storeRepo)
+	dashboardService := dashboard_usecase.NewService(dashboardRepo, c.location)
 
-	surveyRepo := survey_mongo.NewRepo(database.Collection(c.surveyCollection))
-	surveyService := survey_usecase.NewService(surveyRepo)
+	estimateService := estimate_usecase.NewService(storeRepo, surveyRepo, c.statsMinSampleSize)
+	refreshCtx, stopRefresh := context.WithCancel(context.Background())
+	defer stopRefresh()
+	go refreshEstimator(refreshCtx, estimateService, c.estimateRefresh, c.logger)
+	go refreshRankings(refreshCtx, statisticsService, c.rankingRefresh, c.logger)
 
-	handler := interfaces_http.NewHandler(storeService, surveyService)
-	router := interfaces_http.NewRouter(handler, c.allowedOrigins)
+	handler := interfaces_http.NewHandler(
+		storeService,
+		surveyService,
+		submissionService,
+		adminService,
+		auditService,
+		statisticsService,
+		voteService,
+		dashboardService,
+		estimateService,
+	)
+	router := interfaces_http.NewRouter(handler, c.allowedOrigins, verifier)
 	srv := interfaces_http.NewServer(c.addr, router)
 
 	serverErrors := make(chan error, 1)
@@ -100,6 +211,59 @@ func main() {
 	}
 }
 
+// refreshEstimator は稼ぎの推定モデルを起動直後と interval ごとに作り直す。ctx がキャンセルされるまで戻らない。
+// 作り直しに失敗した場合は直前のモデルを使い続け、次の周期で再試行する。
+func refreshEstimator(ctx context.Context, service estimate_usecase.Service, interval time.Duration, logger *log.Logger) {
+	if interval <= 0 {
+		interval = estimate_usecase.DefaultRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := service.Refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.Printf("failed to refresh earnings estimator: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshRankings は店舗ランキングを起動直後と interval ごとに作り直す。ctx がキャンセルされるまで戻らない。
+// 作り直しに失敗した場合は直前のランキングを使い続け、次の周期で再試行する。
+func refreshRankings(ctx context.Context, service statistics_usecase.Service, interval time.Duration, logger *log.Logger) {
+	if interval <= 0 {
+		interval = statistics_usecase.DefaultRankingRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := service.RefreshRankings(ctx); err != nil && ctx.Err() == nil {
+			logger.Printf("failed to refresh store rankings: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// connectMongo は MongoDB に接続し、疎通を確認したクライアントを返す。失敗した場合は終了する。
+func connectMongo(ctx context.Context, c config) *mongo.Client {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(c.mongoURI))
+	if err != nil {
+		c.logger.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+
+	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		c.logger.Fatalf("failed to ping MongoDB: %v", err)
+	}
+	return client
+}
+
 // loadConfig は環境変数を読み込み、アプリケーション設定を生成する。
 // 文字列/リスト/Duration のパースは補助関数に委譲している。
 func loadConfig() config {
@@ -110,16 +274,59 @@ func loadConfig() config {
 		surveyCollection = "surveys"
 	}
 
+	storeDeletePolicy, err := store_usecase.ParseDeletePolicy(envOrDefault("STORE_DELETE_POLICY", string(store_usecase.DeletePolicyBlock)))
+	if err != nil {
+		logger.Fatalf("invalid STORE_DELETE_POLICY: %v", err)
+	}
+
+	duplicatePolicy, err := survey_usecase.ParseDuplicatePolicy(envOrDefault("SURVEY_DUPLICATE_POLICY", string(survey_usecase.DuplicatePolicyAllow)))
+	if err != nil {
+		logger.Fatalf("invalid SURVEY_DUPLICATE_POLICY: %v", err)
+	}
+
+	location, err := time.LoadLocation(envOrDefault("TIMEZONE", "Asia/Tokyo"))
+	if err != nil {
+		logger.Fatalf("invalid TIMEZONE: %v", err)
+	}
+
+	// VOTE_HASH_SALT は投票を受け付けるサーバーでのみ必須とし、使わないサブコマンドでは未設定でも起動できるようにする。
+	voteHashSalt := strings.TrimSpace(os.Getenv("VOTE_HASH_SALT"))
+
 	return config{
-		addr:             envOrDefault("HTTP_ADDR", ":8080"),
-		mongoURI:         envOrDefault("MONGO_URI", "mongodb://mongo:27017"),
-		mongoDatabase:    envOrDefault("MONGO_DB", "makoto-club"),
-		storeCollection:  envOrDefault("STORE_COLLECTION", "stores"),
-		surveyCollection: surveyCollection,
-		connectTimeout:   durationFromEnv("MONGO_CONNECT_TIMEOUT", 10*time.Second),
-		shutdownTimeout:  durationFromEnv("HTTP_SHUTDOWN_TIMEOUT", 15*time.Second),
-		allowedOrigins:   listFromEnv("HTTP_ALLOWED_ORIGINS", []string{"*"}),
-		logger:           logger,
+		addr:                 envOrDefault("HTTP_ADDR", ":8080"),
+		mongoURI:             envOrDefault("MONGO_URI", "mongodb://mongo:27017"),
+		mongoDatabase:        envOrDefault("MONGO_DB", "makoto-club"),
+		storeCollection:      envOrDefault("STORE_COLLECTION", "stores"),
+		surveyCollection:     surveyCollection,
+		submissionCollection: envOrDefault("SUBMISSION_COLLECTION", "submissions"),
+		adminCollection:      envOrDefault("ADMIN_COLLECTION", "admins"),
+		auditCollection:      envOrDefault("AUDIT_COLLECTION", "audit_logs"),
+		voteCollection:       envOrDefault("VOTE_COLLECTION", "survey_votes"),
+		voteHashSalt:         voteHashSalt,
+		storeDeletePolicy:    storeDeletePolicy,
+		duplicatePolicy:      duplicatePolicy,
+		duplicateWindow:      durationFromEnv("SURVEY_DUPLICATE_WINDOW", survey_usecase.DefaultDuplicateWindow),
+		statsMinSampleSize:   intFromEnv("STATS_MIN_SAMPLE_SIZE", statistics_usecase.DefaultMinSampleSize),
+		estimateRefresh:      durationFromEnv("ESTIMATE_REFRESH_INTERVAL", estimate_usecase.DefaultRefreshInterval),
+		rankingRefresh:       durationFromEnv("RANKING_REFRESH_INTERVAL", statistics_usecase.DefaultRankingRefreshInterval),
+		location:             location,
+		connectTimeout:       durationFromEnv("MONGO_CONNECT_TIMEOUT", 10*time.Second),
+		shutdownTimeout:      durationFromEnv("HTTP_SHUTDOWN_TIMEOUT", 15*time.Second),
+		allowedOrigins:       listFromEnv("HTTP_ALLOWED_ORIGINS", []string{"*"}),
+		adminAuth: auth.Config{
+			Algorithm:     envOrDefault("ADMIN_JWT_ALGORITHM", auth.AlgorithmHS256),
+			Secret:        strings.TrimSpace(os.Getenv("ADMIN_JWT_SECRET")),
+			PublicKeyPEM:  fileFromEnv(logger, "ADMIN_JWT_PUBLIC_KEY_FILE"),
+			PrivateKeyPEM: fileFromEnv(logger, "ADMIN_JWT_PRIVATE_KEY_FILE"),
+			Issuer:        strings.TrimSpace(os.Getenv("ADMIN_JWT_ISSUER")),
+			Audience:      strings.TrimSpace(os.Getenv("ADMIN_JWT_AUDIENCE")),
+			ClockSkew:     durationFromEnv("ADMIN_JWT_CLOCK_SKEW", 30*time.Second),
+			AccessTTL:     durationFromEnv("ADMIN_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTTL:    durationFromEnv("ADMIN_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		},
+		bootstrapEmail: strings.TrimSpace(os.Getenv("ADMIN_BOOTSTRAP_EMAIL")),
+		bootstrapPass:  os.Getenv("ADMIN_BOOTSTRAP_PASSWORD"),
+		logger:         logger,
 	}
 }
 
@@ -145,6 +352,34 @@ func durationFromEnv(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+// intFromEnv は環境変数を整数として解釈し、未指定または解釈できない場合はデフォルトを返す。
+func intFromEnv(key string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(key))
+	if raw == "" {
+		return fallback
+	}
+
+	if v, err := strconv.Atoi(raw); err == nil {
+		return v
+	}
+
+	return fallback
+}
+
+// fileFromEnv は環境変数で指定されたファイルの内容を返す。未指定なら空文字を返す。
+// 鍵ファイルなど起動に必須なものを想定しているため、読み込み失敗は致命的エラーとする。
+func fileFromEnv(logger *log.Logger, key string) string {
+	path := strings.TrimSpace(os.Getenv(key))
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		logger.Fatalf("failed to read %s (%s): %v", key, path, err)
+	}
+	return string(data)
+}
+
 // listFromEnv はカンマ区切り文字列をスライスに変換し、空ならデフォルトを返す。
 func listFromEnv(key string, fallback []string) []string {
 	raw := strings.TrimSpace(os.Getenv(key))